@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/repository"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// BoardPage displays tasks of a project as cards in columns by status (kanban board)
+type BoardPage struct {
+	*tview.Flex
+	project    model.Project
+	activePane tview.Primitive
+
+	columns []*tview.List  // List of cards of every column, one for each of model.Statuses
+	cards   [][]model.Task // Tasks of every column
+	focused int            // Index of the focused column
+}
+
+// showBoard opens the board of a project
+func showBoard(project model.Project) {
+	board := &BoardPage{
+		Flex:       tview.NewFlex(),
+		project:    project,
+		activePane: app.GetFocus(),
+	}
+	board.SetInputCapture(board.handleShortcuts)
+
+	if !board.load(0) {
+		return
+	}
+
+	showModal(fmt.Sprintf(
+		"Board: %s (h/l = move card, ←/→ = switch column, Enter = open task, Esc = close)", project.Title,
+	), board, 0, 0)
+	board.focus(board.focused)
+}
+
+// load (re)builds the columns and cards of tasks, selecting the task of selectedID (if not zero)
+func (board *BoardPage) load(selectedID int64) bool {
+	tasks, err := taskRepo.GetAllByProject(board.project)
+	if err != nil && err != repository.ErrNotFound {
+		statusBar.showForSeconds("[red]Could not load tasks: "+err.Error(), 5)
+		return false
+	}
+
+	board.cards = make([][]model.Task, len(model.Statuses))
+	for _, task := range tasks {
+		column := util.AtArrayPosition(task.CurrentStatus(), model.Statuses)
+		board.cards[column] = append(board.cards[column], task)
+	}
+
+	board.Clear()
+	board.columns = make([]*tview.List, len(model.Statuses))
+	for i, status := range model.Statuses {
+		column := tview.NewList().ShowSecondaryText(false).SetSelectedFocusOnly(true)
+		column.SetSelectedBackgroundColor(tcell.ColorDarkBlue)
+		column.SetBorder(true).SetTitle(fmt.Sprintf(" [%s]%s[-] (%d) ", statusColor(status), status, len(board.cards[i])))
+
+		for j, task := range board.cards[i] {
+			column.AddItem(makeCardTitle(task), "", 0, nil)
+			if task.ID == selectedID {
+				column.SetCurrentItem(j)
+				board.focused = i
+			}
+		}
+
+		column.SetSelectedFunc(func(index int, _, _ string, _ rune) { board.openTask() })
+		board.columns[i] = column
+		board.AddItem(column, 0, 1, i == board.focused)
+	}
+
+	return true
+}
+
+func makeCardTitle(task model.Task) string {
+	pin := ""
+	if task.Pinned {
+		pin = "📌 "
+	}
+
+	return fmt.Sprintf("[%s]%s%s", getTaskTitleColor(task), pin, getTaskTitleWithTicket(task))
+}
+
+func (board *BoardPage) handleShortcuts(event *tcell.EventKey) *tcell.EventKey {
+	// Column may be focused by mouse too
+	for i, column := range board.columns {
+		if column.HasFocus() {
+			board.focused = i
+		}
+	}
+
+	switch event.Key() {
+	case tcell.KeyEsc:
+		board.close()
+		return nil
+	case tcell.KeyLeft, tcell.KeyBacktab:
+		board.focus(board.focused - 1)
+		return nil
+	case tcell.KeyRight, tcell.KeyTab:
+		board.focus(board.focused + 1)
+		return nil
+	}
+
+	column := board.columns[board.focused]
+	switch event.Rune() {
+	case 'h':
+		board.moveCard(-1)
+		return nil
+	case 'l':
+		board.moveCard(1)
+		return nil
+	case 'j':
+		column.SetCurrentItem(column.GetCurrentItem() + 1)
+		return nil
+	case 'k':
+		column.SetCurrentItem(column.GetCurrentItem() - 1)
+		return nil
+	case 'b':
+		board.close()
+		return nil
+	}
+
+	return event
+}
+
+// focus moves focus to column i, if exists
+func (board *BoardPage) focus(i int) {
+	if i < 0 || i >= len(board.columns) {
+		return
+	}
+
+	board.focused = i
+	app.SetFocus(board.columns[i])
+}
+
+// selectedTask is the task of the selected card in focused column, nil if the column is empty
+func (board *BoardPage) selectedTask() *model.Task {
+	cards := board.cards[board.focused]
+	index := board.columns[board.focused].GetCurrentItem()
+	if index < 0 || index >= len(cards) {
+		return nil
+	}
+
+	return &cards[index]
+}
+
+// moveCard moves the selected card to the column next to current one, by direction (-1 = left, 1 = right)
+func (board *BoardPage) moveCard(direction int) {
+	task := board.selectedTask()
+	target := board.focused + direction
+	if task == nil || target < 0 || target >= len(model.Statuses) {
+		return
+	}
+
+	status := model.Statuses[target]
+	change, err := setTaskStatus(task, status)
+	if err != nil {
+		statusBar.showForSeconds("[red]Could not change status: "+err.Error(), 5)
+		return
+	}
+
+	board.focused = target
+	board.load(task.ID)
+	board.focus(target)
+	change.report("Moved to " + status)
+}
+
+// openTask closes the board and opens the selected task in task detail
+func (board *BoardPage) openTask() {
+	task := board.selectedTask()
+	board.close()
+	if task == nil {
+		return
+	}
+
+	for i := range taskPane.tasks {
+		if taskPane.tasks[i].ID == task.ID {
+			taskPane.list.SetCurrentItem(i)
+			taskPane.ActivateTask(i)
+			app.SetFocus(taskDetailPane)
+			return
+		}
+	}
+}
+
+// close goes back to task list, reloaded for changed statuses
+func (board *BoardPage) close() {
+	app.SetRoot(layout, true).EnableMouse(true)
+	if project := projectPane.GetActiveProject(); project != nil && project.ID == board.project.ID {
+		taskPane.LoadProjectTasks(*project)
+	}
+	app.SetFocus(board.activePane)
+}
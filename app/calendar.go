@@ -0,0 +1,450 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/repository"
+	"github.com/ajaxray/geek-life/stats"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// calendarMonthWeeks is the number of weeks in month view, enough for any month starting on any weekday
+const calendarMonthWeeks = 6
+
+// CalendarPage displays number of tasks per day in a month (or week) calendar, and the tasks of selected day
+type CalendarPage struct {
+	*tview.Flex
+	activePane tview.Primitive
+
+	grid     *tview.Grid
+	days     []*tview.TextView // Cells of days in grid, from start
+	dayList  *tview.List
+	weekView bool
+
+	dayScroll *listScroll
+
+	start    time.Time    // First day of grid, a Monday
+	selected time.Time    // Selected day
+	dayTasks []model.Task // Tasks of selected day
+	dragFrom int          // Index of the task being dragged from day list, -1 if none
+
+	moved map[int64]model.Task // Moved tasks, with new due dates, by task ID
+}
+
+// showCalendar opens the calendar, with today selected
+func showCalendar() {
+	cal := &CalendarPage{
+		Flex:       tview.NewFlex(),
+		activePane: app.GetFocus(),
+		grid:       tview.NewGrid().SetBorders(true),
+		dayList:    tview.NewList().ShowSecondaryText(false),
+		selected:   toDate(time.Now()),
+		dragFrom:   -1,
+		moved:      make(map[int64]model.Task),
+	}
+	// Grid is transparent by default, borders of the other view (month/week) would be left over
+	cal.grid.SetBackgroundColor(tview.Styles.PrimitiveBackgroundColor)
+	cal.dayScroll = &listScroll{list: cal.dayList}
+	cal.dayList.SetSelectedBackgroundColor(tcell.ColorDarkBlue)
+	cal.dayList.SetBorder(true)
+	cal.dayList.SetSelectedFunc(func(int, string, string, rune) { cal.openTask() })
+
+	cal.AddItem(cal.grid, 0, 1, true).
+		AddItem(cal.dayList, 45, 0, false)
+	cal.SetBorder(true)
+	cal.SetInputCapture(cal.handleShortcuts)
+	cal.SetMouseCapture(cal.handleMouse)
+
+	cal.render(0)
+
+	// Title changes with the period shown, set by render
+	showModal("", cal, 0, 0)
+	app.SetFocus(cal.grid)
+}
+
+// render (re)builds the grid of days around the selected day,
+// and lists tasks of the selected day, selecting the task of selectedID (if not zero)
+func (cal *CalendarPage) render(selectedID int64) {
+	weeks, period := calendarMonthWeeks, "month"
+	cal.start = stats.StartOfWeek(time.Date(cal.selected.Year(), cal.selected.Month(), 1, 0, 0, 0, 0, time.Local))
+	title := cal.selected.Format("January 2006")
+	if cal.weekView {
+		weeks, period = 1, "week"
+		cal.start = stats.StartOfWeek(cal.selected)
+		title = "Week of " + cal.start.Format(dateLayoutHuman)
+	}
+	cal.SetTitle(fmt.Sprintf(
+		" Calendar: %s (arrows = day/week, [/] = %s, o = today, v = month/week, Tab = tasks, Esc = close) ",
+		title, period,
+	))
+
+	days := weeks * 7
+	last := cal.start.AddDate(0, 0, days-1)
+	tasks, err := taskRepo.GetAllByDateRange(cal.start, last)
+	if err != nil && err != repository.ErrNotFound {
+		statusBar.showForSeconds("[red]Could not load tasks: "+err.Error(), 5)
+	}
+	repository.SortTasks(tasks)
+	repository.SortByDue(tasks, time.Local)
+
+	tasksOfDay := make([][]model.Task, days)
+	for _, task := range tasks {
+		if i := cal.dayIndex(task.DueDay(time.Local)); i >= 0 && i < days {
+			tasksOfDay[i] = append(tasksOfDay[i], task)
+		}
+	}
+
+	cal.grid.Clear()
+	rows := []int{1}
+	for i := 0; i < weeks; i++ {
+		rows = append(rows, 0)
+	}
+	cal.grid.SetRows(rows...).SetColumns(0, 0, 0, 0, 0, 0, 0)
+	for i := 0; i < 7; i++ {
+		weekday := tview.NewTextView().SetTextAlign(tview.AlignCenter).SetTextColor(tcell.ColorYellow).
+			SetText(cal.start.AddDate(0, 0, i).Format("Monday"))
+		cal.grid.AddItem(weekday, 0, i, 1, 1, 0, 0, false)
+	}
+
+	cal.days = make([]*tview.TextView, days)
+	for i := range cal.days {
+		cal.days[i] = tview.NewTextView().SetDynamicColors(true).
+			SetText(cal.makeDayText(cal.start.AddDate(0, 0, i), tasksOfDay[i]))
+		cal.grid.AddItem(cal.days[i], 1+i/7, i%7, 1, 1, 0, 0, false)
+	}
+	cal.highlightSelected()
+
+	cal.loadDay(selectedID)
+}
+
+// makeDayText shows the date, number of tasks (red if overdue) and titles of tasks of a day, as many as fit
+func (cal *CalendarPage) makeDayText(day time.Time, tasks []model.Task) string {
+	dateColor := "white"
+	switch {
+	case day.Equal(toDate(time.Now())):
+		dateColor = "yellow"
+	case !cal.weekView && day.Month() != cal.selected.Month():
+		dateColor = "gray"
+	}
+
+	open, overdue := 0, 0
+	now := time.Now()
+	for _, task := range tasks {
+		if !task.Completed {
+			open++
+		}
+		if task.IsOverdue(now) {
+			overdue++
+		}
+	}
+
+	text := fmt.Sprintf("[%s::b]%d[-::-]", dateColor, day.Day())
+	switch {
+	case overdue > 0:
+		text += fmt.Sprintf(" [red::b]%d overdue[-::-]", overdue)
+	case len(tasks) > 0:
+		text += fmt.Sprintf(" [gray](%d/%d done)[-]", len(tasks)-open, len(tasks))
+	}
+
+	for _, task := range tasks {
+		title := tview.Escape(task.Title)
+		if dueTime := formatDueTime(task); dueTime != "" {
+			title = dueTime + " " + title
+		}
+		text += fmt.Sprintf("\n[%s]%s[-]", getTaskTitleColor(task), title)
+	}
+
+	return text
+}
+
+// dayIndex is the index of a day in grid, counted from start. Rounded, as days around DST changes are not 24 hours.
+func (cal *CalendarPage) dayIndex(day time.Time) int {
+	return int(math.Round(toDate(day).Sub(cal.start).Hours() / 24))
+}
+
+func (cal *CalendarPage) highlightSelected() {
+	selected := cal.dayIndex(cal.selected)
+	for i, day := range cal.days {
+		if i == selected {
+			day.SetBackgroundColor(tcell.ColorDarkBlue)
+		} else {
+			day.SetBackgroundColor(tview.Styles.PrimitiveBackgroundColor)
+		}
+	}
+}
+
+// loadDay lists tasks of the selected day, selecting the task of selectedID (if not zero)
+func (cal *CalendarPage) loadDay(selectedID int64) {
+	tasks, err := taskRepo.GetAllByDate(cal.selected)
+	if err != nil && err != repository.ErrNotFound {
+		statusBar.showForSeconds("[red]Could not load tasks: "+err.Error(), 5)
+	}
+	repository.SortTasks(tasks)
+	repository.SortByDue(tasks, time.Local)
+	cal.dayTasks = tasks
+
+	cal.dayList.Clear()
+	cal.dayList.SetTitle(fmt.Sprintf(" %s (%d) ", cal.selected.Format(dateLayoutHuman), len(tasks)))
+	for i, task := range tasks {
+		cal.dayList.AddItem(makeAgendaTitle(task), "", 0, nil)
+		if task.ID == selectedID {
+			cal.dayList.SetCurrentItem(i)
+		}
+	}
+}
+
+// makeAgendaTitle is the listing title of a task in calendar, with its project as tasks of many projects are listed
+func makeAgendaTitle(task model.Task) string {
+	checkbox := "[ []"
+	if task.Completed {
+		checkbox = "[x[]"
+	}
+
+	title := tview.Escape(task.Title)
+	if dueTime := formatDueTime(task); dueTime != "" {
+		title = dueTime + " " + title
+	}
+	title = fmt.Sprintf("[%s]%s %s", getTaskTitleColor(task), checkbox, title)
+	if project, err := projectRepo.GetByID(task.ProjectID); err == nil {
+		title += " [gray]- " + tview.Escape(project.Title)
+	}
+	return title
+}
+
+// selectDay moves selection to a day, showing another month or week if needed
+func (cal *CalendarPage) selectDay(day time.Time) {
+	cal.selected = toDate(day)
+	// Second week of month view is always in the month, first one may start in previous month
+	if i := cal.dayIndex(cal.selected); i < 0 || i >= len(cal.days) ||
+		!cal.weekView && cal.selected.Month() != cal.start.AddDate(0, 0, 7).Month() {
+		cal.render(0)
+		return
+	}
+
+	cal.highlightSelected()
+	cal.loadDay(0)
+}
+
+// shiftPeriod moves selection to the same day of previous/next month (or week in week view), by direction -1 or 1
+func (cal *CalendarPage) shiftPeriod(direction int) {
+	if cal.weekView {
+		cal.selectDay(cal.selected.AddDate(0, 0, 7*direction))
+		return
+	}
+
+	// First day of the month, then the same day if exists in that month (e.g. no 31 Feb)
+	month := time.Date(cal.selected.Year(), cal.selected.Month()+time.Month(direction), 1, 0, 0, 0, 0, time.Local)
+	day := cal.selected.Day()
+	if lastDay := month.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	cal.selectDay(month.AddDate(0, 0, day-1))
+}
+
+func (cal *CalendarPage) handleShortcuts(event *tcell.EventKey) *tcell.EventKey {
+	if cal.dayList.HasFocus() {
+		return cal.handleDayListShortcuts(event)
+	}
+
+	switch event.Key() {
+	case tcell.KeyEsc:
+		cal.close()
+		return nil
+	case tcell.KeyLeft:
+		cal.selectDay(cal.selected.AddDate(0, 0, -1))
+		return nil
+	case tcell.KeyRight:
+		cal.selectDay(cal.selected.AddDate(0, 0, 1))
+		return nil
+	case tcell.KeyUp:
+		cal.selectDay(cal.selected.AddDate(0, 0, -7))
+		return nil
+	case tcell.KeyDown:
+		cal.selectDay(cal.selected.AddDate(0, 0, 7))
+		return nil
+	case tcell.KeyPgUp:
+		cal.shiftPeriod(-1)
+		return nil
+	case tcell.KeyPgDn:
+		cal.shiftPeriod(1)
+		return nil
+	case tcell.KeyEnter, tcell.KeyTab:
+		app.SetFocus(cal.dayList)
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'h':
+		cal.selectDay(cal.selected.AddDate(0, 0, -1))
+	case 'l':
+		cal.selectDay(cal.selected.AddDate(0, 0, 1))
+	case 'k':
+		cal.selectDay(cal.selected.AddDate(0, 0, -7))
+	case 'j':
+		cal.selectDay(cal.selected.AddDate(0, 0, 7))
+	case '[':
+		cal.shiftPeriod(-1)
+	case ']':
+		cal.shiftPeriod(1)
+	case 'o':
+		cal.selectDay(time.Now())
+	case 'v':
+		cal.weekView = !cal.weekView
+		cal.render(0)
+	default:
+		return event
+	}
+
+	return nil
+}
+
+func (cal *CalendarPage) handleDayListShortcuts(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEsc, tcell.KeyTab, tcell.KeyBacktab:
+		app.SetFocus(cal.grid)
+		return nil
+	}
+
+	index := cal.dayList.GetCurrentItem()
+	switch event.Rune() {
+	case 'j':
+		cal.dayList.SetCurrentItem(index + 1)
+	case 'k':
+		cal.dayList.SetCurrentItem(index - 1)
+	case 'h':
+		cal.moveTask(index, cal.selected.AddDate(0, 0, -1))
+	case 'l':
+		cal.moveTask(index, cal.selected.AddDate(0, 0, 1))
+	case 'H':
+		cal.moveTask(index, cal.selected.AddDate(0, 0, -7))
+	case 'L':
+		cal.moveTask(index, cal.selected.AddDate(0, 0, 7))
+	case 'd':
+		cal.promptMoveTask(index)
+	default:
+		return event
+	}
+
+	return nil
+}
+
+// handleMouse selects the clicked day, and moves a task dragged from day list to the day it's dropped on
+func (cal *CalendarPage) handleMouse(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+	x, y := event.Position()
+
+	switch action {
+	case tview.MouseScrollUp, tview.MouseScrollDown:
+		cal.dayScroll.scrolled(action, x, y)
+	case tview.MouseLeftDown:
+		cal.dragFrom = -1
+		if cal.dayList.InRect(x, y) {
+			cal.dragFrom = cal.dayScroll.indexAt(y)
+		}
+	case tview.MouseLeftUp:
+		from := cal.dragFrom
+		cal.dragFrom = -1
+		if day, ok := cal.dayAt(x, y); ok && from != -1 {
+			cal.moveTask(from, day)
+			// Consumed by capture, the event does not redraw the screen
+			app.ForceDraw()
+			return action, nil
+		}
+	case tview.MouseLeftClick:
+		if day, ok := cal.dayAt(x, y); ok {
+			cal.selectDay(day)
+			app.SetFocus(cal.grid)
+			app.ForceDraw()
+			return action, nil
+		}
+	}
+
+	return action, event
+}
+
+// dayAt finds the day of the grid cell at screen position x, y
+func (cal *CalendarPage) dayAt(x, y int) (time.Time, bool) {
+	for i, day := range cal.days {
+		if day.InRect(x, y) {
+			return cal.start.AddDate(0, 0, i), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// moveTask changes due date of the task at index of day list to day, and follows it there
+func (cal *CalendarPage) moveTask(index int, day time.Time) {
+	if index < 0 || index >= len(cal.dayTasks) {
+		return
+	}
+
+	task := cal.dayTasks[index]
+	day = toDate(day)
+	if day.Equal(cal.selected) {
+		return
+	}
+	task.SetDueDay(day)
+	if err := taskRepo.Update(&task); err != nil {
+		statusBar.showForSeconds("[red]Could not update due date: "+err.Error(), 5)
+		return
+	}
+	cal.moved[task.ID] = task
+
+	// Counts of both days change, so the grid is rendered again
+	cal.selected = day
+	cal.render(task.ID)
+	statusBar.showForSeconds(fmt.Sprintf("[lime]Moved %s to %s", task.Title, day.Format(dateLayoutHuman)), 3)
+}
+
+// promptMoveTask asks for the date to move the task at index of day list to
+func (cal *CalendarPage) promptMoveTask(index int) {
+	if index < 0 || index >= len(cal.dayTasks) {
+		return
+	}
+
+	showInputPrompt("Move task to date", "Due (e.g. fri, 2w, dec 3): ", cal.selected.Format(dateLayoutISO), func(text string) {
+		date, err := util.ParseDate(text, time.Now())
+		if err != nil || date.IsZero() {
+			statusBar.showForSeconds("[red]Could not understand date, try "+util.DateHelp, 8)
+			return
+		}
+		cal.moveTask(index, date)
+	})
+}
+
+// openTask closes the calendar and opens the selected task in its project
+func (cal *CalendarPage) openTask() {
+	index := cal.dayList.GetCurrentItem()
+	if index < 0 || index >= len(cal.dayTasks) {
+		return
+	}
+
+	task := cal.dayTasks[index]
+	cal.close()
+	selectSearchResult(0, []SearchResult{{Type: "task", Title: task.Title, TaskID: task.ID, ProjectID: task.ProjectID}}, nil)
+}
+
+// close goes back to task list, with due dates of moved tasks updated
+func (cal *CalendarPage) close() {
+	app.SetRoot(layout, true).EnableMouse(true)
+
+	if len(cal.moved) > 0 {
+		for i := range taskPane.tasks {
+			if moved, ok := cal.moved[taskPane.tasks[i].ID]; ok {
+				taskPane.tasks[i] = moved
+			}
+		}
+		taskPane.refreshListing()
+		if taskPane.activeTask != nil {
+			taskDetailPane.SetTask(taskPane.activeTask)
+		}
+	}
+
+	app.SetFocus(cal.activePane)
+}
@@ -13,7 +13,7 @@ import (
 
 	"github.com/ajaxray/geek-life/model"
 	"github.com/ajaxray/geek-life/repository"
-	repo "github.com/ajaxray/geek-life/repository/storm"
+	"github.com/ajaxray/geek-life/ticketmanager"
 	"github.com/ajaxray/geek-life/util"
 )
 
@@ -28,16 +28,23 @@ var (
 	taskDetailPane    *TaskDetailPane
 	projectDetailPane *ProjectDetailPane
 
-	db          *storm.DB
-	projectRepo repository.ProjectRepository
-	taskRepo    repository.TaskRepository
+	db           *storm.DB // nil with storage backends other than bolt
+	projectRepo  repository.ProjectRepository
+	taskRepo     repository.TaskRepository
+	activityRepo repository.ActivityRepository
+	tickets      *ticketmanager.Registry
 
 	// Flag variables
-	dbFile string
+	dbFile     string
+	openTarget string
 )
 
 func init() {
 	flag.StringVarP(&dbFile, "db-file", "d", "", "Specify DB file path manually.")
+	flag.StringVar(&openTarget, "open", "", "Start at a project or task, by its link (geek-life://task/<UUID>) or UUID.")
+
+	// Flags after a command name (e.g. `migrate --status`) belong to that command
+	flag.CommandLine.SetInterspersed(false)
 }
 
 func main() {
@@ -48,20 +55,36 @@ func main() {
 	if err := util.InitLogger(); err != nil {
 		fmt.Printf("Warning: Failed to initialize logger: %v\n", err)
 	}
+	zoneErr := util.SetTimeZone()
+	if zoneErr != nil {
+		util.LogError("%v", zoneErr)
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", zoneErr)
+	}
 
-	db = util.ConnectStorm(dbFile)
-	defer func() {
-		if err := db.Close(); err != nil {
-			util.LogIfError(err, "Error in closing storm Db")
-		}
-	}()
+	if util.InArray(flag.Arg(0), detachedCommands) {
+		util.FatalIfError(runCommand(flag.Arg(0), flag.Args()[1:]), "Command %s failed", flag.Arg(0))
+		return
+	}
 
-	if flag.NArg() > 0 && flag.Arg(0) == "migrate" {
-		migrate(db)
-		fmt.Println("Database migrated successfully!")
+	closeStorage := openStorage()
+	defer closeStorage()
+
+	// Migrations and backups are of the bolt database
+	if db != nil && !util.InArray(flag.Arg(0), rawDBCommands) {
+		_, err := runMigrations()
+		util.FatalIfError(err, "Could not migrate database. Run `geek-life migrate` to retry")
+	}
+
+	if flag.NArg() > 0 {
+		projectRepo = projectRepo.WithSource(model.ActivitySourceCLI)
+		taskRepo = taskRepo.WithSource(model.ActivitySourceCLI)
+		util.FatalIfError(runCommand(flag.Arg(0), flag.Args()[1:]), "Command %s failed", flag.Arg(0))
 	} else {
-		projectRepo = repo.NewProjectRepository(db)
-		taskRepo = repo.NewTaskRepository(db)
+		stopBackups := startBackups()
+		defer stopBackups()
+		stopReminders := startReminders()
+		defer stopReminders()
+		purgeExpiredTrash()
 
 		layout = tview.NewFlex().SetDirection(tview.FlexRow).
 			AddItem(makeTitleBar(), 2, 1, false).
@@ -70,6 +93,14 @@ func main() {
 			AddItem(prepareStatusBar(app), 1, 1, false)
 
 		setKeyboardShortcuts()
+		if zoneErr != nil {
+			statusBar.showForSeconds("[yellow]"+zoneErr.Error(), 10)
+		}
+		if openTarget != "" {
+			if err := openLink(openTarget); err != nil {
+				statusBar.showForSeconds("[red]Could not open: "+err.Error(), 10)
+			}
+		}
 
 		if err := app.SetRoot(layout, true).EnableMouse(true).Run(); err != nil {
 			panic(err)
@@ -78,14 +109,6 @@ func main() {
 
 }
 
-func migrate(database *storm.DB) {
-	util.FatalIfError(database.ReIndex(&model.Project{}), "Error in migrating Projects")
-	util.FatalIfError(database.ReIndex(&model.Task{}), "Error in migrating Tasks")
-
-	fmt.Println("Migration completed. Start geek-life normally.")
-	os.Exit(0)
-}
-
 func setKeyboardShortcuts() *tview.Application {
 	return app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if ignoreKeyEvt() {
@@ -97,6 +120,15 @@ func setKeyboardShortcuts() *tview.Application {
 		case '/':
 			ShowSearchModal()
 			return nil
+		case 'u':
+			undoLastAction()
+			return nil
+		}
+
+		switch event.Key() {
+		case tcell.KeyCtrlS:
+			copyReportWithPrompt()
+			return nil
 		}
 
 		switch unicode.ToLower(event.Rune()) {
@@ -227,8 +259,12 @@ func updateProjectHeaderWithContext(dynamicListType string) {
 			icon, title = "📆", "Tomorrow"
 		case "upcoming":
 			icon, title = "🗓️", "Upcoming (next 7 days)"
+		case "available":
+			icon, title = "✅", "Available (open and started tasks)"
 		case "unscheduled":
 			icon, title = "📋", "Unscheduled tasks"
+		case "trash":
+			icon, title = "🗑️", "Trash"
 		default:
 			icon, title = "📋", "Dynamic Task List"
 		}
@@ -258,6 +294,40 @@ func AskYesNo(text string, f func()) {
 				app.SetFocus(activePane)
 			})
 
+	showModal("", modal, 0, 0)
+}
+
+// showModal shows content over the layout, in a centered area of width x height cells, with a border titled title.
+// Size 0 takes the whole screen, except the status bar line, to keep showing results of actions.
+// Content with an empty title keeps its own border. Closed by setting layout as root again.
+func showModal(title string, content tview.Primitive, width, height int) {
+	if box, ok := content.(interface {
+		SetBorder(show bool) *tview.Box
+		SetTitle(title string) *tview.Box
+	}); ok && title != "" {
+		box.SetBorder(true)
+		box.SetTitle(" " + title + " ")
+	}
+
+	column := tview.NewFlex().SetDirection(tview.FlexRow)
+	if height > 0 {
+		column.AddItem(nil, 0, 1, false).
+			AddItem(content, height, 0, true).
+			AddItem(nil, 0, 1, false)
+	} else {
+		column.AddItem(content, 0, 1, true).
+			AddItem(nil, 1, 0, false)
+	}
+
+	modal := tview.NewFlex()
+	if width > 0 {
+		modal.AddItem(nil, 0, 1, false).
+			AddItem(column, width, 0, true).
+			AddItem(nil, 0, 1, false)
+	} else {
+		modal.AddItem(column, 0, 1, true)
+	}
+
 	pages := tview.NewPages().
 		AddPage("background", layout, true, true).
 		AddPage("modal", modal, true, true)
@@ -377,13 +447,7 @@ func ShowSearchModal() {
 		AddItem(searchInput, 3, 0, true).
 		AddItem(resultsList, 0, 1, false)
 
-	modalFlex.SetBorder(true).SetTitle("Search Tasks and Projects (ESC to close, Tab to switch)")
-
-	// Show modal
-	pages := tview.NewPages().
-		AddPage("background", layout, true, true).
-		AddPage("search", modalFlex, true, true)
-	_ = app.SetRoot(pages, true).EnableMouse(true)
+	showModal("Search Tasks and Projects (ESC to close, Tab to switch)", modalFlex, 0, 0)
 	app.SetFocus(searchInput)
 }
 
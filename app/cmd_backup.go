@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ajaxray/geek-life/backup"
+	"github.com/ajaxray/geek-life/util"
+)
+
+func init() {
+	registerCommand("backup", "Manage database snapshots: list | create | prune | restore <snapshot>", backupCommand)
+}
+
+func backupCommand(args []string) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	config := backup.ConfigFromEnv()
+	dir := backup.Dir(db)
+
+	switch args[0] {
+	case "list":
+		snapshots, err := backup.List(dir)
+		if err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			fmt.Println("No snapshot found in " + dir)
+			return nil
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "SNAPSHOT\tCREATED\tREASON\tSIZE")
+		for _, snapshot := range snapshots {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%d KB\n",
+				snapshot.Name, snapshot.CreatedAt.Format("2006-01-02 15:04:05"), snapshot.Reason, snapshot.Size/1024)
+		}
+		return writer.Flush()
+
+	case "create":
+		path, err := backup.Create(db, "manual")
+		if err != nil {
+			return err
+		}
+		fmt.Println("Snapshot created: " + path)
+		return nil
+
+	case "prune":
+		removed, err := backup.Prune(dir, config)
+		fmt.Printf("Removed %d snapshots.\n", len(removed))
+		return err
+
+	case "restore":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: geek-life backup restore <snapshot>")
+		}
+		return restoreSnapshot(dir, args[1])
+
+	default:
+		return fmt.Errorf("unknown backup action %q. Use list, create, prune or restore", args[0])
+	}
+}
+
+// restoreSnapshot replaces the database with a snapshot, keeping current state as "pre-restore" snapshot
+func restoreSnapshot(dir, name string) error {
+	snapshot, err := backup.Find(dir, name)
+	if err != nil {
+		return err
+	}
+
+	// Check before touching the current database
+	if err := backup.Verify(snapshot.Path); err != nil {
+		return err
+	}
+
+	current, err := backup.Create(db, "pre-restore")
+	if err != nil {
+		return fmt.Errorf("could not backup current database: %w", err)
+	}
+
+	dbPath := db.Bolt.Path()
+	if err := db.Close(); err != nil {
+		return err
+	}
+
+	if err := backup.Restore(snapshot.Path, dbPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored %s.\nPrevious state saved as %s\n", snapshot.Name, current)
+	return nil
+}
+
+// startBackups takes the startup snapshot and schedules periodic ones. Returns a function to stop scheduling.
+func startBackups() func() {
+	if db == nil {
+		// Other storages are plain files, to be versioned with git instead
+		return func() {}
+	}
+
+	config := backup.ConfigFromEnv()
+
+	if config.OnStartup {
+		if err := backup.Rotate(db, "startup", config); err != nil {
+			util.LogError("Startup backup failed: %v", err)
+		}
+	}
+
+	return backup.Schedule(db, config, func(err error) {
+		util.LogError("Scheduled backup failed: %v", err)
+	})
+}
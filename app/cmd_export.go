@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/asdine/storm/v3"
+	flag "github.com/spf13/pflag"
+
+	"github.com/ajaxray/geek-life/export"
+	"github.com/ajaxray/geek-life/ical"
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/taskwarrior"
+)
+
+func init() {
+	registerCommand("export", "Export tasks: export <format|ics|taskwarrior> [--project | --list]. Lists formats without arguments.", exportCommand)
+}
+
+func exportCommand(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	projectName := flags.String("project", "", "Export tasks of a project (title, ID or UUID)")
+	listName := flags.String("list", "", "Export tasks of a dynamic list: today, tomorrow, upcoming, available or unscheduled")
+	output := flags.StringP("output", "o", "", "Write to a file instead of stdout")
+	events := flags.Bool("events", false, "ics: Also add tasks as all-day events, for calendars not showing tasks")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		return printExportFormats()
+	}
+
+	title, tasks, err := exportTasks(*projectName, *listName)
+	if err != nil {
+		return err
+	}
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	var content string
+	switch flags.Arg(0) {
+	case "ics":
+		var calendar strings.Builder
+		err = ical.Write(&calendar, "Geek-life: "+title, tasks, projects, ical.Options{Events: *events})
+		content = calendar.String()
+	case "taskwarrior":
+		var tasksJSON strings.Builder
+		err = taskwarrior.Write(&tasksJSON, tasks, projects)
+		content = tasksJSON.String()
+	default:
+		content, err = export.Render(flags.Arg(0), export.NewDocument(title, tasks, projects))
+	}
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		fmt.Print(content)
+		return nil
+	}
+
+	if err := os.WriteFile(*output, []byte(content), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d tasks to %s\n", len(tasks), *output)
+	return nil
+}
+
+// exportTasks finds tasks of a project, a dynamic list or (without both) all tasks, with a title for them
+func exportTasks(projectName, listName string) (string, []model.Task, error) {
+	switch {
+	case projectName != "":
+		project, err := findProject(projectName)
+		if err != nil {
+			return "", nil, err
+		}
+		tasks, err := taskRepo.GetAllByProject(project)
+		if err != nil && err != storm.ErrNotFound {
+			return "", nil, err
+		}
+		return project.Title, tasks, nil
+	case listName != "":
+		tasks, title, err := dynamicListTasks(listName)
+		if err != nil && err != storm.ErrNotFound {
+			return "", nil, err
+		}
+		return strings.TrimSpace(title), tasks, nil
+	default:
+		tasks, err := taskRepo.GetAll()
+		return "All tasks", tasks, err
+	}
+}
+
+func printExportFormats() error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "FORMAT\tEXTENSION\tTEMPLATE")
+	for _, format := range export.Formats() {
+		source := "built-in"
+		if format.Custom {
+			source = "custom"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", format.Name, format.Extension, source)
+	}
+	fmt.Fprintln(writer, "ics\tics\tbuilt-in (iCalendar, use --events to add all-day events)")
+	fmt.Fprintln(writer, "taskwarrior\tjson\tbuilt-in (for `task import`)")
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println("\nAdd formats as templates in " + export.TemplateDir() + " (e.g. confluence.html.tmpl)")
+	return nil
+}
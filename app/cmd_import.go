@@ -0,0 +1,269 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	flag "github.com/spf13/pflag"
+
+	"github.com/ajaxray/geek-life/ical"
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/repository"
+	"github.com/ajaxray/geek-life/taskwarrior"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// defaultImportProject keeps imported tasks that have neither category nor calendar name
+const defaultImportProject = "Imported"
+
+// legacyUIDPattern matches UIDs of tasks exported to calendars before tasks had UUIDs (see ical.UID)
+var legacyUIDPattern = regexp.MustCompile(`^task-\d+@geek-life$`)
+
+func init() {
+	registerCommand("import", "Import tasks from other tools: import ics|taskwarrior <file>", importCommand)
+}
+
+// importResult counts what an import has done
+type importResult struct {
+	created, updated, unchanged, skipped int
+}
+
+func (r importResult) String() string {
+	return fmt.Sprintf("%d created, %d updated, %d unchanged, %d skipped",
+		r.created, r.updated, r.unchanged, r.skipped)
+}
+
+// importer saves imported tasks, matching them by UUID, so that importing again updates them instead of duplicating.
+// Tasks already imported keep their project, as they may have been moved since.
+type importer struct {
+	tasks       repository.TaskRepository
+	projectRepo repository.ProjectRepository
+	projects    map[string]model.Project // Projects by title
+	result      importResult
+}
+
+func newImporter() *importer {
+	return &importer{
+		tasks:       taskRepo.WithSource(model.ActivitySourceImport),
+		projectRepo: projectRepo.WithSource(model.ActivitySourceImport),
+		projects:    make(map[string]model.Project),
+	}
+}
+
+func importCommand(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return fmt.Errorf("usage: geek-life import ics|taskwarrior <file>")
+	}
+
+	file, err := os.Open(flags.Arg(1))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	imp := newImporter()
+	switch flags.Arg(0) {
+	case "ics":
+		calendar, err := ical.Read(file)
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", flags.Arg(1), err)
+		}
+		err = imp.importCalendar(calendar)
+		fmt.Println("Imported tasks: " + imp.result.String())
+		return err
+	case "taskwarrior":
+		tasks, err := taskwarrior.Read(file)
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", flags.Arg(1), err)
+		}
+		err = imp.importTaskwarrior(tasks)
+		fmt.Println("Imported tasks: " + imp.result.String())
+		return err
+	default:
+		return fmt.Errorf("unknown import format %q, use ics or taskwarrior", flags.Arg(0))
+	}
+}
+
+// importCalendar saves VTODOs as tasks, in projects named as their first category or the calendar.
+// UID of VTODO is kept as Task.UUID.
+func (imp *importer) importCalendar(calendar ical.Calendar) error {
+	for _, todo := range calendar.Todos {
+		projectTitle := calendar.Name
+		if len(todo.Categories) > 0 {
+			projectTitle = todo.Categories[0]
+		}
+
+		// Tasks exported before having a UUID got the one made of their UID (see migration.backfillUUIDs)
+		UUID := todo.UID
+		if legacyUIDPattern.MatchString(UUID) {
+			UUID = util.NameUUID(UUID)
+		}
+
+		todo := todo
+		if err := imp.save(UUID, projectTitle, func(task *model.Task) { applyTodo(task, todo) }); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importTaskwarrior saves pending and completed Taskwarrior tasks in their projects.
+// Deleted tasks and templates of recurring tasks are skipped.
+func (imp *importer) importTaskwarrior(tasks []taskwarrior.Task) error {
+	aliases, err := imp.taskwarriorAliases()
+	if err != nil {
+		return err
+	}
+
+	for _, twTask := range tasks {
+		if twTask.Status == taskwarrior.StatusDeleted || twTask.Status == taskwarrior.StatusRecurring || twTask.UUID == "" {
+			imp.result.skipped++
+			continue
+		}
+
+		UUID := strings.ToLower(twTask.UUID)
+		if task, ok := aliases[UUID]; ok {
+			UUID = task.UUID
+		}
+
+		twTask := twTask
+		if err := imp.save(UUID, twTask.Project, twTask.Apply); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// taskwarriorAliases maps the UUIDs made by taskwarrior.UUID to tasks without a Taskwarrior compatible UUID,
+// to recognise them when imported back
+func (imp *importer) taskwarriorAliases() (map[string]model.Task, error) {
+	tasks, err := imp.tasks.GetAll()
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	aliases := make(map[string]model.Task)
+	for _, task := range tasks {
+		if UUID := taskwarrior.UUID(task); UUID != task.UUID {
+			aliases[UUID] = task
+		}
+	}
+
+	return aliases, nil
+}
+
+// save creates the task with UUID in the project titled projectTitle, or updates the existing one.
+// apply copies imported fields to the task. Tasks in Trash are not updated.
+func (imp *importer) save(UUID, projectTitle string, apply func(task *model.Task)) error {
+	existing, err := imp.tasks.GetByUUID(UUID)
+	switch {
+	case err == storm.ErrNotFound:
+		project, err := imp.project(projectTitle)
+		if err != nil {
+			return err
+		}
+
+		task := model.Task{ProjectID: project.ID, UUID: UUID}
+		apply(&task)
+		if err := imp.tasks.CreateTask(&task); err != nil {
+			return err
+		}
+		imp.result.created++
+	case err != nil:
+		return err
+	case existing.IsDeleted():
+		imp.result.skipped++
+	default:
+		task := existing
+		apply(&task)
+		if reflect.DeepEqual(task, existing) {
+			imp.result.unchanged++
+			return nil
+		}
+		if err := imp.tasks.Update(&task); err != nil {
+			return err
+		}
+		imp.result.updated++
+	}
+
+	return nil
+}
+
+// applyTodo copies fields of a VTODO to task
+func applyTodo(task *model.Task, todo ical.Todo) {
+	task.Title = strings.TrimSpace(todo.Summary)
+	if task.IsLinked() {
+		// Calendars exported by geek-life show ticket key with title
+		task.Title = strings.TrimSuffix(task.Title, " ["+task.ExternalKey+"]")
+	}
+	if task.Title == "" {
+		task.Title = "(untitled)"
+	}
+	task.Details = todo.Description
+
+	// Dates are read as of local time zone, timestamps are kept at the time of the day in task's zone
+	task.SetDueDay(time.Time{})
+	if todo.Due != nil && todo.DueIsDate {
+		task.SetDueDay(*todo.Due)
+	} else if todo.Due != nil {
+		due := todo.Due.In(task.Location())
+		task.SetDueDay(due)
+		task.DueTime = due.Format("15:04")
+	}
+	task.SetStartDay(time.Time{})
+	if todo.Start != nil {
+		task.SetStartDay(todo.Start.Local())
+	}
+
+	if todo.Completed != task.Completed {
+		task.CompletedAt = todo.CompletedAt
+	}
+	task.Completed = todo.Completed
+
+	// Cancelled tasks are completed too, but not done. Others get the status of being completed or not.
+	if todo.Cancelled {
+		task.Status = model.StatusCancelled
+	} else if task.Status == model.StatusCancelled {
+		task.Status = ""
+	}
+}
+
+// project finds a project (not in Trash) by title, creating it if not found
+func (imp *importer) project(title string) (model.Project, error) {
+	if title = strings.TrimSpace(title); title == "" {
+		title = defaultImportProject
+	}
+	if project, ok := imp.projects[title]; ok {
+		return project, nil
+	}
+
+	all, err := imp.projectRepo.GetAll()
+	if err != nil && err != storm.ErrNotFound {
+		return model.Project{}, err
+	}
+	for _, project := range all {
+		if project.Title == title {
+			imp.projects[title] = project
+			return project, nil
+		}
+	}
+
+	project, err := imp.projectRepo.Create(title, "")
+	if err != nil {
+		return project, err
+	}
+
+	imp.projects[title] = project
+	return project, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/ajaxray/geek-life/backup"
+	"github.com/ajaxray/geek-life/migration"
+)
+
+func init() {
+	registerCommand("migrate", "Apply pending database migrations. Use --status to list them, --refresh-remote to fix ticket links.", migrateCommand)
+}
+
+func migrateCommand(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	showStatus := flags.Bool("status", false, "List applied and pending migrations")
+	refreshRemote := flags.Bool("refresh-remote", false, "Fix keys and creation dates of linked epics from ticket providers")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *showStatus {
+		return printMigrationStatus()
+	}
+
+	applied, err := runMigrations()
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		fmt.Println("Database is up to date.")
+	} else {
+		fmt.Printf("Database migrated successfully! Applied %d migrations.\n", len(applied))
+	}
+
+	if *refreshRemote {
+		return refreshRemoteData()
+	}
+	return nil
+}
+
+// refreshRemoteData fixes data of linked projects from ticket providers, see migration.RefreshRemote
+func refreshRemoteData() error {
+	results, err := migration.RefreshRemote(db)
+	for _, result := range results {
+		fmt.Printf("%s: fixed %d, failed %d\n", result.Name, result.Fixed, result.Failed)
+	}
+
+	return err
+}
+
+// runMigrations applies pending migrations, taking a backup of the database first
+func runMigrations() ([]migration.Migration, error) {
+	pending, err := migration.Pending(db)
+	if err != nil || len(pending) == 0 {
+		return nil, err
+	}
+
+	if _, err := backup.Create(db, "pre-migration"); err != nil {
+		return nil, fmt.Errorf("could not backup database before migration: %w", err)
+	}
+
+	return migration.Run(db)
+}
+
+func printMigrationStatus() error {
+	statuses, err := migration.Statuses(db)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tNAME\tSTATUS")
+	for _, status := range statuses {
+		state := "pending"
+		if status.AppliedAt != nil {
+			state = "applied " + status.AppliedAt.Format("2006-01-02 15:04")
+		} else if status.Applied {
+			state = "applied"
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s\n", status.Version, status.Name, state)
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	flag "github.com/spf13/pflag"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/remind"
+	"github.com/ajaxray/geek-life/repository/markdown"
+	repo "github.com/ajaxray/geek-life/repository/storm"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// dbLockTimeout is how long `remind` waits for the database, locked while the app is open
+const dbLockTimeout = 2 * time.Second
+
+// errStorageBusy is of the database locked by the app, which notifies of reminders itself while open
+var errStorageBusy = errors.New("database is in use by geek-life app, reminders are notified by the app meanwhile")
+
+func init() {
+	registerCommand("remind", "Notify of task reminders: remind [--daemon] [--notifier notify-send|bell|hook] [--command CMD] [--list]", remindCommand)
+}
+
+func remindCommand(args []string) error {
+	config := remind.ConfigFromEnv()
+	flags := flag.NewFlagSet("remind", flag.ContinueOnError)
+	daemon := flags.Bool("daemon", false, "Keep running, checking reminders every --interval")
+	list := flags.Bool("list", false, "List reminders of the next 7 days, without notifying")
+	flags.StringVar(&config.Notifier, "notifier", config.Notifier, "notify-send, bell or hook. Default is notify-send if installed, or else bell.")
+	flags.StringVar(&config.Command, "command", config.Command, "Executable for notify-send (taking the same arguments), or shell command of hook")
+	flags.DurationVar(&config.Interval, "interval", config.Interval, "How often to check for reminders")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *list {
+		return listReminders(config)
+	}
+
+	notifier, err := remind.NewNotifier(config.Notifier, config.Command, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	if !*daemon {
+		tasks, err := readRemindTasks()
+		if err != nil {
+			return err
+		}
+		notified, err := remind.Check(tasks, time.Now(), config, notifier)
+		fmt.Printf("Notified %d reminders\n", notified)
+		return err
+	}
+
+	if config.Interval <= 0 {
+		return fmt.Errorf("invalid interval %s", config.Interval)
+	}
+	fmt.Printf("Notifying reminders by %s, checking every %s (Ctrl+C to stop)\n",
+		remind.ResolveKind(config.Notifier, config.Command), config.Interval)
+
+	stop := remind.Schedule(config, readRemindTasks, notifier, func(err error) {
+		if err == errStorageBusy {
+			util.LogInfo("Skipped checking reminders: %v", err)
+			return
+		}
+		util.LogError("Checking reminders failed: %v", err)
+		fmt.Fprintln(os.Stderr, "Checking reminders failed:", err)
+	})
+	defer stop()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+
+	return nil
+}
+
+// listReminders prints the next reminder of tasks with any in the next 7 days
+func listReminders(config remind.Config) error {
+	tasks, err := readRemindTasks()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	notes := remind.Upcoming(tasks, now, now.AddDate(0, 0, 7), config.DayStart)
+	if len(notes) == 0 {
+		fmt.Println("No reminders in the next 7 days")
+	}
+	for _, note := range notes {
+		fmt.Printf("%s  %s (%s)\n", note.At.Format("Mon 02 Jan 15:04"), note.Title, note.Message)
+	}
+
+	return nil
+}
+
+// readRemindTasks reads tasks from the storage, opening the database only meanwhile,
+// so that the app can be opened while `remind --daemon` is running
+func readRemindTasks() ([]model.Task, error) {
+	switch backend := util.GetEnvStr("STORAGE_BACKEND", storageBolt); backend {
+	case storageMarkdown:
+		store, err := markdown.Open(markdownDir())
+		if err != nil {
+			return nil, err
+		}
+		return markdown.NewTaskRepository(store).GetAll()
+	case storageBolt:
+		conn, err := util.OpenStorm(util.DBPath(dbFile), dbLockTimeout)
+		if err == bolt.ErrTimeout {
+			return nil, errStorageBusy
+		} else if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		tasks, err := repo.NewTaskRepository(conn).GetAll()
+		if err == storm.ErrNotFound {
+			return nil, nil
+		}
+		return tasks, err
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_BACKEND %q", backend)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/rivo/tview"
+	flag "github.com/spf13/pflag"
+
+	"github.com/ajaxray/geek-life/report"
+	"github.com/ajaxray/geek-life/util"
+)
+
+func init() {
+	registerCommand("report", "Markdown status report: standup | weekly | templates. See --help for options.", reportCommand)
+}
+
+func reportCommand(args []string) error {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	date := flags.String("date", time.Now().Format(dateLayoutISO), "Day of the standup, or any day of the week (yyyy-mm-dd)")
+	copyToClipboard := flags.BoolP("copy", "c", false, "Copy report to clipboard")
+	output := flags.StringP("output", "o", "", "Write report to a file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	switch flags.Arg(0) {
+	case report.StandupTemplate, report.WeeklyTemplate:
+	case "templates":
+		return writeReportTemplates()
+	default:
+		return fmt.Errorf("unknown report %q, use standup, weekly or templates", flags.Arg(0))
+	}
+
+	day, err := time.ParseInLocation(dateLayoutISO, *date, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid --date: %w", err)
+	}
+
+	content, err := buildReport(flags.Arg(0), day)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *output != "":
+		if err := os.WriteFile(*output, []byte(content), 0644); err != nil {
+			return err
+		}
+		fmt.Println("Report written to " + *output)
+	case *copyToClipboard:
+		if err := clipboard.WriteAll(content); err != nil {
+			return err
+		}
+		fmt.Println("Report copied to clipboard.")
+	default:
+		fmt.Print(content)
+	}
+
+	return nil
+}
+
+// buildReport renders the named report (standup or weekly) of tasks not in Trash
+func buildReport(name string, date time.Time) (string, error) {
+	tasks, err := taskRepo.GetAll()
+	if err != nil {
+		return "", err
+	}
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		return "", err
+	}
+
+	if name == report.WeeklyTemplate {
+		return report.Render(name, report.BuildWeekly(tasks, projects, date))
+	}
+
+	return report.Render(name, report.BuildStandup(tasks, projects, date))
+}
+
+func writeReportTemplates() error {
+	written, err := report.WriteDefaultTemplates()
+	for _, path := range written {
+		fmt.Println("Created " + path)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Customise the templates in " + util.TemplateDir())
+	return nil
+}
+
+// copyReportWithPrompt asks which report to build and copies it to clipboard
+func copyReportWithPrompt() {
+	activePane := app.GetFocus()
+	closeModal := func() {
+		app.SetRoot(layout, true).EnableMouse(true)
+		app.SetFocus(activePane)
+	}
+
+	modal := tview.NewModal().
+		SetText("Copy a report to clipboard").
+		AddButtons([]string{"Standup", "Weekly", "Cancel"}).
+		SetDoneFunc(func(_ int, buttonLabel string) {
+			closeModal()
+
+			var name string
+			switch buttonLabel {
+			case "Standup":
+				name = report.StandupTemplate
+			case "Weekly":
+				name = report.WeeklyTemplate
+			default:
+				return
+			}
+
+			content, err := buildReport(name, time.Now())
+			if err == nil {
+				err = clipboard.WriteAll(content)
+			}
+			if err != nil {
+				statusBar.showForSeconds("[red]Could not make report: "+err.Error(), 5)
+				return
+			}
+			statusBar.showForSeconds("[lime]"+buttonLabel+" report copied. Try Pasting anywhere.", 5)
+		})
+
+	showModal("", modal, 0, 0)
+}
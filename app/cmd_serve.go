@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/asdine/storm/v3"
+	flag "github.com/spf13/pflag"
+
+	"github.com/ajaxray/geek-life/ical"
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/util"
+)
+
+func init() {
+	registerCommand("serve", "Serve tasks over HTTP for local apps, e.g. /calendar.ics for calendar subscription, /tasks/<UUID> as JSON", serveCommand)
+}
+
+func serveCommand(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", util.GetEnvStr("SERVE_ADDR", "127.0.0.1:8421"), "Address to listen on")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/calendar.ics", serveCalendar)
+	mux.HandleFunc("/projects/", serveProject)
+	mux.HandleFunc("/tasks/", serveTask)
+
+	fmt.Printf("Serving on http://%s (Ctrl+C to stop)\n", *addr)
+	fmt.Printf("Subscribe to http://%s/calendar.ics in your calendar app\n", *addr)
+	return http.ListenAndServe(*addr, mux)
+}
+
+// serveCalendar writes dated tasks as iCalendar, fresh on every request.
+// Query parameters: project (title, ID or UUID), list (dynamic list) and events=1 to add all-day events.
+func serveCalendar(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	title, tasks, err := exportTasks(query.Get("project"), query.Get("list"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var calendar strings.Builder
+	opts := ical.Options{Events: query.Get("events") == "1"}
+	if err := ical.Write(&calendar, "Geek-life: "+title, tasks, projects, opts); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	_, _ = w.Write([]byte(calendar.String()))
+}
+
+// serveProject writes the project of UUID in path (/projects/<UUID>) with its tasks as JSON
+func serveProject(w http.ResponseWriter, r *http.Request) {
+	project, err := projectRepo.GetByUUID(strings.TrimPrefix(r.URL.Path, "/projects/"))
+	if err != nil || project.IsDeleted() {
+		http.NotFound(w, r)
+		return
+	}
+	tasks, err := taskRepo.GetAllByProject(project)
+	if err != nil && err != storm.ErrNotFound {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, struct {
+		model.Project
+		Tasks []model.Task `json:"tasks"`
+	}{project, tasks})
+}
+
+// serveTask writes the task of UUID in path (/tasks/<UUID>) as JSON
+func serveTask(w http.ResponseWriter, r *http.Request) {
+	task, err := taskRepo.GetByUUID(strings.TrimPrefix(r.URL.Path, "/tasks/"))
+	if err != nil || task.IsDeleted() {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeJSON(w, task)
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(value)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/stats"
+	"github.com/ajaxray/geek-life/util"
+)
+
+func init() {
+	registerCommand("stats", "Show created/completed task statistics. See --help for options.", statsCommand)
+}
+
+func statsCommand(args []string) error {
+	today := stats.StartOfDay(time.Now())
+
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	from := flags.String("from", today.AddDate(0, 0, -29).Format(dateLayoutISO), "First day (yyyy-mm-dd)")
+	to := flags.String("to", today.Format(dateLayoutISO), "Last day (yyyy-mm-dd)")
+	projectName := flags.String("project", "", "Limit to a project (title, ID or UUID)")
+	by := flags.String("by", "week", "Group counts by day or week")
+	asJSON := flags.Bool("json", false, "Print as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *by != "day" && *by != "week" {
+		return fmt.Errorf("invalid --by value %q, use day or week", *by)
+	}
+
+	opts, err := parseStatsOptions(*from, *to, *projectName)
+	if err != nil {
+		return err
+	}
+
+	report, err := computeStats(opts)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	return printStats(report, *by)
+}
+
+func parseStatsOptions(from, to, projectName string) (stats.Options, error) {
+	var opts stats.Options
+	var err error
+
+	if opts.From, err = time.ParseInLocation(dateLayoutISO, from, time.Local); err != nil {
+		return opts, fmt.Errorf("invalid --from date: %w", err)
+	}
+	if opts.To, err = time.ParseInLocation(dateLayoutISO, to, time.Local); err != nil {
+		return opts, fmt.Errorf("invalid --to date: %w", err)
+	}
+	if opts.To.Before(opts.From) {
+		return opts, fmt.Errorf("--to date is before --from date")
+	}
+
+	if projectName != "" {
+		project, err := findProject(projectName)
+		if err != nil {
+			return opts, err
+		}
+		opts.ProjectID = project.ID
+	}
+
+	return opts, nil
+}
+
+// findProject finds a project by its UUID, ID or title
+func findProject(titleOrID string) (model.Project, error) {
+	if util.IsUUID(titleOrID) {
+		if project, err := projectRepo.GetByUUID(titleOrID); err == nil && !project.IsDeleted() {
+			return project, nil
+		}
+	}
+	if id, err := strconv.ParseInt(titleOrID, 10, 64); err == nil {
+		if project, err := projectRepo.GetByID(id); err == nil && !project.IsDeleted() {
+			return project, nil
+		}
+	}
+
+	project, err := projectRepo.GetByTitle(titleOrID)
+	if err != nil || project.IsDeleted() {
+		return project, fmt.Errorf("project %q not found", titleOrID)
+	}
+
+	return project, nil
+}
+
+// computeStats makes statistics report of all tasks (not in Trash)
+func computeStats(opts stats.Options) (stats.Report, error) {
+	tasks, err := taskRepo.GetAll()
+	if err != nil {
+		return stats.Report{}, err
+	}
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		return stats.Report{}, err
+	}
+
+	return stats.Compute(tasks, projects, opts, time.Now()), nil
+}
+
+func printStats(report stats.Report, by string) error {
+	fmt.Printf("Tasks from %s to %s\n\n", report.From.Format(dateLayoutISO), report.To.Format(dateLayoutISO))
+	fmt.Printf("Created:   %4d  %s\n", report.Created, stats.Sparkline(report.CreatedPerDay()))
+	fmt.Printf("Completed: %4d  %s\n", report.Completed, stats.Sparkline(report.CompletedPerDay()))
+	fmt.Printf("Overdue:   %4d\n", report.Overdue)
+	fmt.Printf("Average time to complete: %.1f days\n\n", report.AverageDaysToComplete)
+
+	periods, label := report.Weeks, "WEEK OF"
+	if by == "day" {
+		periods, label = report.Days, "DAY"
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "%s\tCREATED\tCOMPLETED\n", label)
+	for _, period := range periods {
+		fmt.Fprintf(writer, "%s\t%d\t%d\n", period.Start.Format(dateLayoutISO), period.Created, period.Completed)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	writer = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "PROJECT\tCREATED\tCOMPLETED\tOPEN\tOVERDUE")
+	for _, project := range report.Projects {
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t%d\n",
+			project.Title, project.Created, project.Completed, project.Open, project.Overdue)
+	}
+
+	return writer.Flush()
+}
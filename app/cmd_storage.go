@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/ajaxray/geek-life/repository/markdown"
+)
+
+func init() {
+	registerCommand("storage", "Copy data of the database to Markdown storage: storage markdown [--dir DIR]", storageCommand)
+}
+
+func storageCommand(args []string) error {
+	flags := flag.NewFlagSet("storage", flag.ContinueOnError)
+	dir := flags.String("dir", "", "Markdown directory (default MARKDOWN_DIR or ~/.geek-life/notes)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.Arg(0) != storageMarkdown {
+		return fmt.Errorf("unknown storage %q, use %s", flags.Arg(0), storageMarkdown)
+	}
+	if db == nil {
+		return fmt.Errorf("data is copied from %s storage, unset STORAGE_BACKEND to use it", storageBolt)
+	}
+	if *dir == "" {
+		*dir = markdownDir()
+	}
+
+	return copyToMarkdown(*dir)
+}
+
+// copyToMarkdown writes all projects, tasks (including Trash) and task history to a Markdown directory
+func copyToMarkdown(dir string) error {
+	projects, tasks, err := allRecords()
+	if err != nil {
+		return err
+	}
+	activities, err := activityRepo.GetAllByDateRange(time.Time{}, time.Now())
+	if err != nil {
+		return err
+	}
+
+	store, err := markdown.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := store.WriteAll(projects, tasks, activities); err != nil {
+		return err
+	}
+
+	fmt.Printf("Copied %d projects and %d tasks to %s\n", len(projects), len(tasks), dir)
+	fmt.Printf("Set STORAGE_BACKEND=%s (and MARKDOWN_DIR=%s, if not default) to use it.\n", storageMarkdown, dir)
+	return nil
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/ajaxray/geek-life/backup"
+	"github.com/ajaxray/geek-life/gitsync"
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/util"
+)
+
+func init() {
+	registerCommand("sync", "Sync projects and tasks between machines: sync git [--dir DIR] [--remote NAME]", syncCommand)
+}
+
+func syncCommand(args []string) error {
+	flags := flag.NewFlagSet("sync", flag.ContinueOnError)
+	dir := flags.String("dir", util.GetEnvStr("SYNC_DIR", filepath.Join(util.AppDir(), "sync")), "Git repository to sync through")
+	remote := flags.String("remote", util.GetEnvStr("SYNC_REMOTE", "origin"), "Git remote to pull from and push to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.Arg(0) != "git" {
+		return fmt.Errorf("unknown sync method %q, use git", flags.Arg(0))
+	}
+
+	return syncGit(*dir, *remote)
+}
+
+// syncResult counts changes made to local data by a sync
+type syncResult struct {
+	created, updated, deleted, conflicts int
+}
+
+// syncGit merges local projects and tasks with the ones in the repository in dir (as pulled from remote),
+// commits and pushes the merged result and saves it locally.
+// The merge base is the state last synced from this repository, so that records new on either side are
+// added, instead of being taken as deleted on the other.
+func syncGit(dir, remote string) error {
+	repo, err := gitsync.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	hasRemote := repo.HasRemote(remote)
+	branch, err := repo.Branch()
+	if err != nil {
+		return err
+	}
+	remoteRef := remote + "/" + branch
+
+	// Theirs is the remote branch if any, otherwise what is committed (e.g. by syncing another database)
+	theirsRef := repo.Head()
+	if hasRemote {
+		if err := repo.Fetch(remote); err != nil {
+			return err
+		}
+		if repo.RefExists(remoteRef) {
+			theirsRef = remoteRef
+		}
+	}
+
+	local, err := localSnapshot()
+	if err != nil {
+		return err
+	}
+	base, err := readSnapshot(repo, repo.LastSynced())
+	if err != nil {
+		return err
+	}
+	theirs, err := readSnapshot(repo, theirsRef)
+	if err != nil {
+		return err
+	}
+
+	// Records changed only in update time (by applying last sync) are not local changes
+	ours := local
+	ours.Projects = unchangedAs(local.Projects, base.Projects)
+	ours.Tasks = unchangedAs(local.Tasks, base.Tasks)
+	merged := gitsync.Merge(base, ours, theirs)
+	// and unchanged records are committed as they are, to not make commits for nothing
+	synced := merged.Snapshot
+	synced.Projects = unchangedAs(synced.Projects, theirs.Projects)
+	synced.Tasks = unchangedAs(synced.Tasks, theirs.Tasks)
+
+	if theirsRef != "" && theirsRef != repo.Head() {
+		// Local commits not pushed are in local data already, so they are replaced by the merged state
+		if err := repo.Reset(theirsRef); err != nil {
+			return err
+		}
+	}
+	files, err := synced.Files()
+	if err != nil {
+		return err
+	}
+	if err := repo.WriteFiles(files, "projects", "tasks"); err != nil {
+		return err
+	}
+	hostname, _ := os.Hostname()
+	if _, err := repo.CommitAll("Sync from " + hostname); err != nil {
+		return err
+	}
+
+	result := syncResult{conflicts: merged.Conflicts}
+	if !sameRecords(synced.Projects, local.Projects) || !sameRecords(synced.Tasks, local.Tasks) {
+		if err := applySnapshot(synced, local, &result); err != nil {
+			return err
+		}
+	}
+	if err := repo.SetLastSynced(repo.Head()); err != nil {
+		return err
+	}
+
+	if !hasRemote {
+		fmt.Printf("Committed to %s. Add a remote to sync with other machines:\n  git -C %s remote add %s <url>\n",
+			dir, dir, remote)
+		return nil
+	}
+	if err := repo.Push(remote, branch); err != nil {
+		return err
+	}
+
+	fmt.Printf("Synced with %s: %d created, %d updated, %d deleted locally",
+		remoteRef, result.created, result.updated, result.deleted)
+	if result.conflicts > 0 {
+		fmt.Printf(", %d conflicting changes resolved (last updated wins)", result.conflicts)
+	}
+	fmt.Println()
+	return nil
+}
+
+// readSnapshot reads the snapshot committed in ref, empty for empty ref
+func readSnapshot(repo *gitsync.Repo, ref string) (gitsync.Snapshot, error) {
+	files, err := repo.ReadFiles(ref)
+	if err != nil {
+		return gitsync.Snapshot{}, err
+	}
+
+	return gitsync.ReadSnapshot(files)
+}
+
+// unchangedAs copies records, taking the version in previous of the ones equivalent to it
+func unchangedAs(records, previous map[string]gitsync.Record) map[string]gitsync.Record {
+	result := make(map[string]gitsync.Record, len(records))
+	for uuid, record := range records {
+		if old, ok := previous[uuid]; ok && gitsync.Equivalent(record, old) {
+			record = old
+		}
+		result[uuid] = record
+	}
+
+	return result
+}
+
+// sameRecords checks if a and b have equivalent records of the same UUIDs
+func sameRecords(a, b map[string]gitsync.Record) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for uuid, record := range a {
+		if other, ok := b[uuid]; !ok || !gitsync.Equivalent(record, other) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applySnapshot saves synced state locally: creates, updates and deletes (forever) records to match it.
+// local is the state before sync, to leave unchanged records alone.
+func applySnapshot(synced, local gitsync.Snapshot, result *syncResult) error {
+	if db != nil {
+		if _, err := backup.Create(db, "pre-sync"); err != nil {
+			return fmt.Errorf("could not backup database before sync: %w", err)
+		}
+	}
+
+	projects, tasks, err := allRecords()
+	if err != nil {
+		return err
+	}
+	projectIDs := make(map[string]int64, len(projects))
+	for _, project := range projects {
+		projectIDs[project.UUID] = project.ID
+	}
+
+	syncProjects := projectRepo.WithSource(model.ActivitySourceSync)
+	for uuid, record := range synced.Projects {
+		if previous, ok := local.Projects[uuid]; ok && gitsync.Equivalent(record, previous) {
+			continue
+		}
+
+		project, err := record.Project()
+		if err != nil {
+			return err
+		}
+		project.UUID = uuid
+		if id, ok := projectIDs[uuid]; ok {
+			project.ID = id
+			result.updated++
+		} else {
+			created, err := syncProjects.Create(project.Title, uuid)
+			if err != nil {
+				return err
+			}
+			project.ID = created.ID
+			projectIDs[uuid] = created.ID
+			result.created++
+		}
+		if err := syncProjects.Update(&project); err != nil {
+			return err
+		}
+	}
+
+	syncTasks := taskRepo.WithSource(model.ActivitySourceSync)
+	taskIDs := make(map[string]int64, len(tasks))
+	for _, task := range tasks {
+		taskIDs[task.UUID] = task.ID
+	}
+	for uuid, record := range synced.Tasks {
+		if previous, ok := local.Tasks[uuid]; ok && gitsync.Equivalent(record, previous) {
+			continue
+		}
+
+		task, projectUUID, err := record.Task()
+		if err != nil {
+			return err
+		}
+		task.UUID = uuid
+		projectID, ok := projectIDs[projectUUID]
+		if !ok {
+			return fmt.Errorf("project %q of task %s is not in the synced data", projectUUID, uuid)
+		}
+		task.ProjectID = projectID
+
+		if id, ok := taskIDs[uuid]; ok {
+			task.ID = id
+			err = syncTasks.Update(&task)
+			result.updated++
+		} else {
+			err = syncTasks.CreateTask(&task)
+			result.created++
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Purged on another machine
+	for i := range tasks {
+		if _, ok := synced.Tasks[tasks[i].UUID]; !ok {
+			if err := syncTasks.Delete(&tasks[i]); err != nil {
+				return err
+			}
+			result.deleted++
+		}
+	}
+	for i := range projects {
+		if _, ok := synced.Projects[projects[i].UUID]; !ok {
+			if err := syncProjects.Delete(&projects[i]); err != nil {
+				return err
+			}
+			result.deleted++
+		}
+	}
+
+	return nil
+}
+
+// localSnapshot makes a sync snapshot of all local projects and tasks, including Trash
+func localSnapshot() (gitsync.Snapshot, error) {
+	projects, tasks, err := allRecords()
+	if err != nil {
+		return gitsync.Snapshot{}, err
+	}
+
+	return gitsync.NewSnapshot(projects, tasks)
+}
+
+// allRecords lists all projects and tasks, including the ones in Trash
+func allRecords() ([]model.Project, []model.Task, error) {
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	deletedProjects, err := projectRepo.GetAllDeleted()
+	if err != nil {
+		return nil, nil, err
+	}
+	tasks, err := taskRepo.GetAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	deletedTasks, err := taskRepo.GetAllDeleted()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(projects, deletedProjects...), append(tasks, deletedTasks...), nil
+}
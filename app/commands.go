@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ajaxray/geek-life/util"
+)
+
+// command is a non-interactive subcommand, e.g. `geek-life migrate --status`
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{}
+
+// rawDBCommands work on the database as it is, without applying pending migrations first
+var rawDBCommands = []string{"migrate", "backup"}
+
+// detachedCommands open the storage themselves, only while they use it, so that the app can be opened meanwhile
+var detachedCommands = []string{"remind"}
+
+// registerCommand makes a subcommand available from command line
+func registerCommand(name, usage string, run func(args []string) error) {
+	commands[name] = command{usage: usage, run: run}
+}
+
+// runCommand executes the named subcommand with remaining command line arguments
+func runCommand(name string, args []string) error {
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n\n%s", name, commandsUsage())
+	}
+
+	if db == nil && util.InArray(name, rawDBCommands) {
+		return fmt.Errorf("%s works with %s storage only", name, storageBolt)
+	}
+
+	return cmd.run(args)
+}
+
+func commandsUsage() string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var usage strings.Builder
+	usage.WriteString("Available commands:\n")
+	for _, name := range names {
+		usage.WriteString(fmt.Sprintf("  %-10s %s\n", name, commands[name].usage))
+	}
+
+	return usage.String()
+}
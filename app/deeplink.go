@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/atotto/clipboard"
+
+	"github.com/ajaxray/geek-life/util"
+)
+
+// openLink shows the project or task a deep link (or bare UUID) points to
+func openLink(link string) error {
+	target, err := linkTarget(link)
+	if err != nil {
+		return err
+	}
+
+	selectSearchResult(0, []SearchResult{target}, nil)
+	return nil
+}
+
+// linkTarget finds the project or task of a deep link, or of a UUID of either
+func linkTarget(link string) (SearchResult, error) {
+	kind, UUID := "", link
+	if !util.IsUUID(link) {
+		var err error
+		if kind, UUID, err = util.ParseDeepLink(link); err != nil {
+			return SearchResult{}, err
+		}
+	}
+
+	if kind != util.LinkProject {
+		if task, err := taskRepo.GetByUUID(UUID); err == nil {
+			if task.IsDeleted() {
+				return SearchResult{}, fmt.Errorf("task %q is in Trash", task.Title)
+			}
+			return SearchResult{Type: "task", Title: task.Title, TaskID: task.ID, ProjectID: task.ProjectID}, nil
+		}
+	}
+	if kind != util.LinkTask {
+		if project, err := projectRepo.GetByUUID(UUID); err == nil {
+			if project.IsDeleted() {
+				return SearchResult{}, fmt.Errorf("project %q is in Trash", project.Title)
+			}
+			return SearchResult{Type: "project", Title: project.Title, ProjectID: project.ID}, nil
+		}
+	}
+
+	return SearchResult{}, fmt.Errorf("no project or task found for %s", link)
+}
+
+// copyTaskLink copies deep link of the task to clipboard
+func (td *TaskDetailPane) copyTaskLink() {
+	if err := clipboard.WriteAll(util.TaskLink(td.task.UUID)); err != nil {
+		statusBar.showForSeconds("[red]Could not copy link: "+err.Error(), 5)
+		return
+	}
+
+	statusBar.showForSeconds("[lime]Copied link to task. Open it with `geek-life --open <link>`", 5)
+}
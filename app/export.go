@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/atotto/clipboard"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/ajaxray/geek-life/export"
+)
+
+// showExportPicker lists export formats, the picked one is used to copy doc to clipboard
+func showExportPicker(doc export.Document) {
+	activePane := app.GetFocus()
+	closePicker := func() {
+		app.SetRoot(layout, true).EnableMouse(true)
+		app.SetFocus(activePane)
+	}
+
+	picker := tview.NewList().ShowSecondaryText(false)
+
+	formats := export.Formats()
+	for i, format := range formats {
+		label := format.Name
+		if format.Custom {
+			label += " [::d](custom)"
+		}
+
+		picker.AddItem(label, "", rune('1'+i), func(name string) func() {
+			return func() {
+				closePicker()
+				content, err := export.Render(name, doc)
+				if err == nil {
+					err = clipboard.WriteAll(content)
+				}
+				if err != nil {
+					statusBar.showForSeconds("[red]Could not export: "+err.Error(), 5)
+					return
+				}
+				statusBar.showForSeconds(fmt.Sprintf("[lime]Copied as %s. Try Pasting anywhere.", name), 5)
+			}
+		}(format.Name))
+	}
+
+	picker.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			closePicker()
+			return nil
+		}
+		return event
+	})
+
+	showModal("Export "+doc.Title+" (Esc to close)", picker, 40, len(formats)+2)
+	app.SetFocus(picker)
+}
@@ -13,7 +13,8 @@ import (
 // ProjectDetailPane Displays relevant actions of current project
 type ProjectDetailPane struct {
 	*tview.Flex
-	project *model.Project
+	project     *model.Project
+	description *tview.TextView
 }
 
 func removeProjectWithConfirmation() {
@@ -36,10 +37,17 @@ func removeProjectWithConfirmation() {
 	} else {
 		message = fmt.Sprintf("Do you want to delete this project?\n\nThis will also delete %d tasks.", taskCount)
 	}
+	message += "\nIt can be restored from Trash."
 
 	AskYesNo(message, projectPane.RemoveActivateProject)
 }
 
+func editProject() {
+	if projectPane.activeProject != nil {
+		showProjectEditor(projectPane.activeProject)
+	}
+}
+
 func clearCompletedWithConfirmation() {
 	AskYesNo("Do you want to clear completed tasks?", taskPane.ClearCompletedTasks)
 }
@@ -47,17 +55,22 @@ func clearCompletedWithConfirmation() {
 // NewProjectDetailPane Initializes ProjectDetailPane
 func NewProjectDetailPane() *ProjectDetailPane {
 	pane := ProjectDetailPane{
-		Flex: tview.NewFlex().SetDirection(tview.FlexRow),
+		Flex:        tview.NewFlex().SetDirection(tview.FlexRow),
+		description: tview.NewTextView().SetWordWrap(true),
 	}
+	editBtn := makeButton("[::u]E[::-]dit Project", editProject)
 	deleteBtn := makeButton("[::u]D[::-]elete Project", removeProjectWithConfirmation)
 	clearBtn := makeButton("[::u]C[::-]lear Completed Tasks", clearCompletedWithConfirmation)
 
 	deleteBtn.SetBackgroundColor(tcell.ColorRed)
+	pane.description.SetTextColor(tcell.ColorLightGray).SetBorderPadding(1, 0, 1, 1)
 	pane.
+		AddItem(editBtn, 3, 1, false).
+		AddItem(blankCell, 1, 1, false).
 		AddItem(deleteBtn, 3, 1, false).
 		AddItem(blankCell, 1, 1, false).
 		AddItem(clearBtn, 3, 1, false).
-		AddItem(blankCell, 0, 1, false)
+		AddItem(pane.description, 0, 1, false)
 
 	pane.SetBorder(true).SetTitle("[::u]A[::-]ctions")
 
@@ -68,6 +81,7 @@ func NewProjectDetailPane() *ProjectDetailPane {
 func (pd *ProjectDetailPane) SetProject(project *model.Project) {
 	pd.project = project
 	pd.SetTitle("[::b]" + pd.project.Title)
+	pd.description.SetText(project.Description).ScrollToBeginning()
 }
 
 func (pd *ProjectDetailPane) isShowing() bool {
@@ -76,6 +90,9 @@ func (pd *ProjectDetailPane) isShowing() bool {
 
 func (pd *ProjectDetailPane) handleShortcuts(event *tcell.EventKey) *tcell.EventKey {
 	switch unicode.ToLower(event.Rune()) {
+	case 'e':
+		editProject()
+		return nil
 	case 'd':
 		removeProjectWithConfirmation()
 		return nil
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/pgavlin/femto"
+	"github.com/pgavlin/femto/runtime"
+	"github.com/rivo/tview"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/ticketmanager"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// showProjectEditor opens a form to edit title and description (Markdown) of a project
+func showProjectEditor(project *model.Project) {
+	activePane := app.GetFocus()
+	closeEditor := func() {
+		app.SetRoot(layout, true).EnableMouse(true)
+		app.SetFocus(activePane)
+	}
+
+	titleInput := makeLightTextInput("Project title").
+		SetLabel("Title: ").
+		SetText(project.Title)
+
+	description := femto.NewView(makeBufferFromString(project.Description))
+	description.SetRuntimeFiles(runtime.Files)
+	description.SetColorscheme(taskDetailPane.colorScheme)
+	description.SetBorder(true).SetTitle(" Description (Markdown) ")
+
+	hint := tview.NewTextView().SetTextColor(tcell.ColorYellow).
+		SetText(" Tab = switch field, Ctrl+S or Enter (on title) = save, Esc = cancel")
+
+	save := func() {
+		title := strings.TrimSpace(titleInput.GetText())
+		if title == "" {
+			statusBar.showForSeconds("[red]Project title can not be empty", 5)
+			return
+		}
+
+		closeEditor()
+		saveProjectEdit(project, title, description.Buf.String())
+	}
+
+	handleKeys := func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeEditor()
+			return nil
+		case tcell.KeyCtrlS:
+			save()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			if titleInput.HasFocus() {
+				app.SetFocus(description)
+			} else {
+				app.SetFocus(titleInput)
+			}
+			return nil
+		case tcell.KeyEnter:
+			if titleInput.HasFocus() {
+				save()
+				return nil
+			}
+		}
+
+		return event
+	}
+	titleInput.SetInputCapture(handleKeys)
+	description.SetInputCapture(handleKeys)
+
+	form := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(titleInput, 1, 0, true).
+		AddItem(blankCell, 1, 0, false).
+		AddItem(description, 0, 1, false).
+		AddItem(hint, 1, 0, false)
+	showModal("Edit Project", form, 80, 20)
+	app.SetFocus(titleInput)
+}
+
+// saveProjectEdit saves title and description of a project, also to its epic if linked
+func saveProjectEdit(project *model.Project, title, description string) {
+	if title == project.Title && description == project.Description {
+		return
+	}
+
+	project.Title = title
+	project.Description = description
+	if err := projectRepo.Update(project); err != nil {
+		statusBar.showForSeconds("[red]Could not save project: "+err.Error(), 5)
+		return
+	}
+	projectPane.refreshProject(*project)
+
+	if !project.IsLinked() {
+		statusBar.showForSeconds("[lime]Saved project", 3)
+		return
+	}
+
+	tm, provider, err := projectTicketManager(*project)
+	if err == nil {
+		_, err = tm.UpdateEpic(title, description, project.ExternalKey)
+	}
+	if err != nil {
+		util.LogError("Failed to update epic %s: %v", project.ExternalKey, err)
+		statusBar.showForSeconds(
+			fmt.Sprintf("[yellow]Saved project, but could not update %s epic: %s", provider.DisplayName(), err.Error()),
+			8,
+		)
+		return
+	}
+
+	// Remote changes up to the update are in sync, later ones will be pulled.
+	// Update time is of the ticket system, as it's compared with the times of later changes there.
+	if epic, err := tm.DescribeEpic(project.ExternalKey); err != nil {
+		util.LogWarning("Could not fetch epic %s after updating it: %v", project.ExternalKey, err)
+	} else if updatedAt, err := ticketmanager.ParseDate(epic.UpdatedDate); err != nil {
+		util.LogWarning("Could not parse update time '%s' of epic %s: %v", epic.UpdatedDate, project.ExternalKey, err)
+	} else {
+		project.RemoteUpdatedAt = &updatedAt
+		_ = projectRepo.Update(project)
+	}
+	statusBar.showForSeconds(fmt.Sprintf("[lime]Saved project and updated %s epic", provider.DisplayName()), 3)
+}
+
+// pullEpicChanges takes title and description of the epic, if it's updated remotely since last synced.
+// Returns true if the project is changed.
+func pullEpicChanges(project *model.Project, epic ticketmanager.Epic) bool {
+	remoteUpdatedAt, err := ticketmanager.ParseDate(epic.UpdatedDate)
+	if err != nil {
+		return false
+	}
+	if project.RemoteUpdatedAt == nil {
+		// Never synced (e.g. epic created of the project), nothing to pull until changed remotely
+		project.RemoteUpdatedAt = &remoteUpdatedAt
+		return true
+	}
+	if !remoteUpdatedAt.After(*project.RemoteUpdatedAt) {
+		return false
+	}
+
+	project.RemoteUpdatedAt = &remoteUpdatedAt
+	if epic.Title != "" {
+		project.Title = epic.Title
+	}
+	project.Description = epic.Description
+	return true
+}
@@ -23,26 +23,17 @@ type ProjectPane struct {
 	newProject          *tview.InputField
 	repo                repository.ProjectRepository
 	activeProject       *model.Project
-	projectListStarting int // The index in list where project names starts
-	ticketManager       ticketmanager.TicketManager
-	providerType        ticketmanager.ProviderType
+	projectListStarting int   // The index in list where project names starts
 	lastGKeyTime        int64 // Timestamp for tracking double 'g' press
 }
 
 // NewProjectPane initializes
 func NewProjectPane(repo repository.ProjectRepository) *ProjectPane {
 	pane := ProjectPane{
-		Flex:         tview.NewFlex().SetDirection(tview.FlexRow),
-		list:         tview.NewList().ShowSecondaryText(false),
-		newProject:   makeLightTextInput("+[New Project]"),
-		repo:         repo,
-		providerType: ticketmanager.GetProviderType(),
-	}
-
-	if ticketmanager.IsAnyProviderConfigured() {
-		if tm, err := ticketmanager.NewTicketManager(); err == nil {
-			pane.ticketManager = tm
-		}
+		Flex:       tview.NewFlex().SetDirection(tview.FlexRow),
+		list:       tview.NewList().ShowSecondaryText(false),
+		newProject: makeLightTextInput("+[New Project]"),
+		repo:       repo,
 	}
 
 	pane.newProject.SetDoneFunc(func(key tcell.Key) {
@@ -86,7 +77,11 @@ func (pane *ProjectPane) addDynamicLists() {
 	pane.list.AddItem("- Today", "", 0, func() { taskPane.LoadDynamicList("today") })
 	pane.list.AddItem("- Tomorrow", "", 0, func() { taskPane.LoadDynamicList("tomorrow") })
 	pane.list.AddItem("- Upcoming", "", 0, func() { taskPane.LoadDynamicList("upcoming") })
+	pane.list.AddItem("- Available", "", 0, func() { taskPane.LoadDynamicList("available") })
 	pane.list.AddItem("- Unscheduled", "", 0, func() { taskPane.LoadDynamicList("unscheduled") })
+	pane.list.AddItem("- Calendar", "", 0, showCalendar)
+	pane.list.AddItem("- Trash", "", 0, func() { taskPane.LoadTrash() })
+	pane.list.AddItem("- Statistics", "", 0, showStatsPage)
 }
 
 func (pane *ProjectPane) addProjectList() {
@@ -94,7 +89,7 @@ func (pane *ProjectPane) addProjectList() {
 	pane.projectListStarting = pane.list.GetItemCount()
 
 	var err error
-	pane.projects, err = pane.repo.GetAllSortedByJiraDate()
+	pane.projects, err = pane.repo.GetAllSortedByRemoteDate()
 	if err != nil {
 		statusBar.showForSeconds("Could not load Projects: "+err.Error(), 5)
 		return
@@ -165,27 +160,18 @@ func (pane *ProjectPane) handleShortcuts(event *tcell.EventKey) *tcell.EventKey
 		projectindex := selectedIndex - pane.projectListStarting
 		if projectindex >= 0 && projectindex < len(pane.projects) {
 			project := pane.projects[projectindex]
-			if project.Jira != "" && pane.ticketManager != nil {
-				var ticketURL string
-				var providerName string
-
-				switch pane.providerType {
-				case ticketmanager.ProviderJira:
-					jiraConfig := util.GetJiraConfig()
-					ticketURL = fmt.Sprintf("%s/browse/%s", jiraConfig.URL, project.Jira)
-					providerName = "JIRA"
-				case ticketmanager.ProviderLinear:
-					ticketURL = fmt.Sprintf("https://linear.app/team/issue/%s", project.Jira)
-					providerName = "Linear"
-				}
-
-				err := util.OpenInBrowser(ticketURL)
+			_, provider, tmErr := projectTicketManager(project)
+			if project.IsLinked() && tmErr == nil {
+				err := util.OpenInBrowser(ticketmanager.LinkURL(project.ExternalLink))
 				if err != nil {
 					statusBar.showForSeconds("[red]Failed to open browser: "+err.Error(), 5)
 				} else {
-					statusBar.showForSeconds(fmt.Sprintf("[lime]Opened %s epic in browser", providerName), 3)
+					statusBar.showForSeconds(
+						fmt.Sprintf("[lime]Opened %s epic in browser", provider.DisplayName()),
+						3,
+					)
 				}
-			} else if project.Jira == "" {
+			} else if !project.IsLinked() {
 				statusBar.showForSeconds("[yellow]Project has no ticket associated", 3)
 			} else {
 				statusBar.showForSeconds("[yellow]Ticket manager not configured", 3)
@@ -196,20 +182,34 @@ func (pane *ProjectPane) handleShortcuts(event *tcell.EventKey) *tcell.EventKey
 		// Get the project that is currently selected
 		selectedIndex := pane.list.GetCurrentItem()
 		projectindex := selectedIndex - pane.projectListStarting
-		if projectindex >= 0 && projectindex < len(pane.projects) && pane.ticketManager != nil {
+		if projectindex >= 0 && projectindex < len(pane.projects) && tickets.IsConfigured() {
 			project := pane.projects[projectindex]
-			if project.Jira == "" {
-				ticketID, err := pane.ticketManager.CreateEpic(project.Title, project.Title)
+			if !project.IsLinked() {
+				// Unlinked projects are linked with the default provider
+				tm, provider, err := projectTicketManager(project)
+				if err != nil {
+					statusBar.showForSeconds("[red]"+err.Error(), 5)
+					return nil
+				}
+
+				description := project.Description
+				if description == "" {
+					description = project.Title
+				}
+				ticketID, err := tm.CreateEpic(project.Title, description)
 				if err != nil {
 					statusBar.showForSeconds("[red]Failed to create epic: "+err.Error(), 5)
 					return nil
 				}
-				project.Jira = ticketID
+				project.ExternalLink = model.ExternalLink{
+					Provider:    string(provider),
+					ExternalKey: ticketID,
+					ExternalURL: provider.TicketURL(ticketID),
+				}
 				_ = pane.repo.Update(&project)
 
-				providerName := string(pane.providerType)
 				statusBar.showForSeconds(
-					fmt.Sprintf("[lime]Created %s epic: %s", providerName, ticketID),
+					fmt.Sprintf("[lime]Created %s epic: %s", provider.DisplayName(), ticketID),
 					5,
 				)
 			}
@@ -242,16 +242,16 @@ func (pane *ProjectPane) activateProject(idx int) {
 	pane.activeProject = &pane.projects[idx]
 
 	// If this project has a ticket ID but no tasks, try to import them
-	if pane.activeProject.Jira != "" && pane.ticketManager != nil {
+	_, provider, tmErr := projectTicketManager(*pane.activeProject)
+	if pane.activeProject.IsLinked() && tmErr == nil {
 		existingTasks, err := taskRepo.GetAllByProject(*pane.activeProject)
 		if err == nil && len(existingTasks) == 0 {
 			// No tasks exist for this project, try to import from ticket manager
-			providerName := string(pane.providerType)
 			statusBar.showForSeconds(
-				fmt.Sprintf("[yellow]Loading tasks from %s...", providerName),
+				fmt.Sprintf("[yellow]Loading tasks from %s...", provider.DisplayName()),
 				2,
 			)
-			pane.importTasksForEpic(*pane.activeProject, pane.activeProject.Jira)
+			pane.importTasksForEpic(*pane.activeProject, pane.activeProject.ExternalKey)
 		}
 	}
 
@@ -263,25 +263,30 @@ func (pane *ProjectPane) activateProject(idx int) {
 	app.SetFocus(taskPane)
 }
 
-// RemoveActivateProject deletes the currently active project
+// RemoveActivateProject moves the currently active project to Trash, along with its tasks
 func (pane *ProjectPane) RemoveActivateProject() {
 	if pane.activeProject != nil {
-		// Delete all tasks associated with this project first
-		err := taskRepo.DeleteAllByProjectID(pane.activeProject.ID)
+		project := *pane.activeProject
+		deletedAt := time.Now()
+
+		// Move all tasks associated with this project first, with the same deletion time to restore them together
+		err := taskRepo.SoftDeleteAllByProjectID(project.ID, deletedAt)
 		if err != nil {
 			statusBar.showForSeconds("[red]Failed to delete project tasks: "+err.Error(), 5)
 			return
 		}
 
 		// Delete the project itself
-		err = pane.repo.Delete(pane.activeProject)
+		err = pane.repo.SoftDelete(&project, deletedAt)
 		if err != nil {
 			statusBar.showForSeconds("[red]Failed to delete project: "+err.Error(), 5)
 			return
 		}
+		pushUndo("deleting project "+project.Title, func() error { return restoreProject(project) })
 
+		pane.activeProject = nil
 		taskPane.ClearList()
-		statusBar.showForSeconds("[lime]Removed Project: "+pane.activeProject.Title, 5)
+		statusBar.showForSeconds("[lime]Moved Project to Trash: "+project.Title+". Press u to undo.", 5)
 		removeThirdCol()
 		pane.loadListItems(true)
 	}
@@ -303,15 +308,11 @@ func (pane *ProjectPane) GetActiveProject() *model.Project {
 	return pane.activeProject
 }
 
-// importEpicsFromTicketManager imports all epics from ticket manager as projects
+// importEpicsFromTicketManager imports all epics from every configured ticket manager as projects
 func (pane *ProjectPane) importEpicsFromTicketManager() {
-	if pane.ticketManager == nil {
-		providerName := string(pane.providerType)
+	if !tickets.IsConfigured() {
 		statusBar.showForSeconds(
-			fmt.Sprintf(
-				"[red]%s not configured. Set required environment variables.",
-				providerName,
-			),
+			"[red]No ticket provider configured. Set required environment variables.",
 			8,
 		)
 		return
@@ -319,75 +320,112 @@ func (pane *ProjectPane) importEpicsFromTicketManager() {
 
 	// Reload projects to ensure we have the latest data
 	var err error
-	pane.projects, err = pane.repo.GetAllSortedByJiraDate()
+	pane.projects, err = pane.repo.GetAllSortedByRemoteDate()
 	if err != nil {
 		statusBar.showForSeconds("[red]Failed to load projects: "+err.Error(), 5)
 		return
 	}
 
-	epics, err := pane.ticketManager.ListUserEpics()
-	if err != nil {
-		providerName := string(pane.providerType)
-		statusBar.showForSeconds(
-			fmt.Sprintf("[red]Failed to fetch epics from %s: %s", providerName, err.Error()),
-			5,
+	imported := 0
+	updated := 0
+	var providerNames, failures []string
+	for _, provider := range tickets.Providers() {
+		tm, _ := tickets.Get(string(provider))
+		epics, err := tm.ListUserEpics()
+		if err != nil {
+			// Epics of other providers are imported anyway
+			util.LogError("Failed to fetch epics from %s: %v", provider.DisplayName(), err)
+			failures = append(failures, fmt.Sprintf("%s failed: %s", provider.DisplayName(), err.Error()))
+			continue
+		}
+
+		providerNames = append(providerNames, provider.DisplayName())
+		providerImported, providerUpdated := pane.importEpics(provider, epics)
+		imported += providerImported
+		updated += providerUpdated
+	}
+
+	pane.loadListItems(true)
+
+	providerName := strings.Join(providerNames, ", ")
+	color, message := "[lime]", ""
+	if imported > 0 && updated > 0 {
+		message = fmt.Sprintf(
+			"Imported %d new epics and updated %d existing projects with %s IDs",
+			imported,
+			updated,
+			providerName,
 		)
+	} else if imported > 0 {
+		message = fmt.Sprintf("Imported %d user-created epics from %s", imported, providerName)
+	} else if updated > 0 {
+		message = fmt.Sprintf("Updated %d existing projects with %s IDs", updated, providerName)
+	} else if len(providerNames) > 0 {
+		color, message = "[yellow]", "No new user-created epics to import from "+providerName
+	}
+
+	if len(failures) > 0 {
+		color = "[yellow]"
+		if len(providerNames) == 0 {
+			color = "[red]"
+		}
+		var parts []string
+		if message != "" {
+			parts = append(parts, message)
+		}
+		statusBar.showForSeconds(color+strings.Join(append(parts, failures...), "; "), 8)
 		return
 	}
+	statusBar.showForSeconds(color+message, 5)
+}
+
+// importEpics creates or links projects for epics of a provider. Returns imported and updated counts.
+func (pane *ProjectPane) importEpics(
+	provider ticketmanager.ProviderType,
+	epics []ticketmanager.Epic,
+) (imported int, updated int) {
+	deletedProjects, _ := pane.repo.GetAllDeleted()
+	syncProjects := pane.repo.WithSource(model.ActivitySourceSync)
 
-	imported := 0
-	updated := 0
 	for _, epic := range epics {
+		// Projects in Trash are deleted by the user, importing them again would bring them back
+		if isEpicInTrash(provider, epic.Key, deletedProjects) {
+			continue
+		}
+
 		// Check if project already exists with this ticket ID
-		existingProject := pane.findProjectByJiraID(epic.Key)
+		existingProject := pane.findProjectByExternalKey(provider, epic.Key)
 		if existingProject != nil {
 			util.LogInfo("Project already exists for epic %s: %s", epic.Key, existingProject.Title)
-			
-			// If project exists but has no creation date, update it
-			if existingProject.JiraCreatedDate == nil {
-				if createdDate, err := pane.parseJiraDate(epic.CreatedDate); err == nil {
-					existingProject.JiraCreatedDate = &createdDate
-					pane.repo.Update(existingProject)
-					
-					// Update in-memory project list
-					for i := range pane.projects {
-						if pane.projects[i].ID == existingProject.ID {
-							pane.projects[i].JiraCreatedDate = &createdDate
-							break
-						}
-					}
+
+			// If project exists but has no creation date or provider, update it
+			if existingProject.RemoteCreatedAt == nil || existingProject.Provider == "" {
+				existingProject.ExternalLink = ticketmanager.EpicLink(provider, epic)
+				if syncProjects.Update(existingProject) == nil {
+					updated++
+				}
+			} else if pullEpicChanges(existingProject, epic) {
+				// Renamed or described in the ticket system
+				if syncProjects.Update(existingProject) == nil {
 					updated++
 				}
 			}
-			
+
 			// IMPORTANT: Import tasks for existing project too!
 			util.LogInfo("Importing tasks for existing project %s (epic %s)", existingProject.Title, epic.Key)
 			pane.importTasksForEpic(*existingProject, epic.Key)
-			
+
 			continue
 		}
 
 		// Check if there's a project with the same title but no ticket ID
 		existingProjectByTitle := pane.findProjectByTitle(epic.Title)
-		if existingProjectByTitle != nil && existingProjectByTitle.Jira == "" {
-			// Update existing project with ticket ID and creation date
-			existingProjectByTitle.Jira = epic.Key
-			
-			// Use creation date directly from epic list
-			if createdDate, err := pane.parseJiraDate(epic.CreatedDate); err == nil {
-				existingProjectByTitle.JiraCreatedDate = &createdDate
-			}
-			
-			err := pane.repo.Update(existingProjectByTitle)
+		if existingProjectByTitle != nil && !existingProjectByTitle.IsLinked() {
+			// Link existing project with the epic, including its creation date
+			existingProjectByTitle.ExternalLink = ticketmanager.EpicLink(provider, epic)
+
+			err := syncProjects.Update(existingProjectByTitle)
 			if err == nil {
-				// Update the in-memory project list
-				for i := range pane.projects {
-					if pane.projects[i].ID == existingProjectByTitle.ID {
-						pane.projects[i].Jira = epic.Key
-						pane.projects[i].JiraCreatedDate = existingProjectByTitle.JiraCreatedDate
-						break
-					}
-				}
 				// Import tasks for this epic using the updated project
 				pane.importTasksForEpic(*existingProjectByTitle, epic.Key)
 				updated++
@@ -395,13 +433,8 @@ func (pane *ProjectPane) importEpicsFromTicketManager() {
 			continue
 		}
 
-		// Create new project from epic with ticket ID and creation date
-		var createdDate *time.Time
-		if parsed, err := pane.parseJiraDate(epic.CreatedDate); err == nil {
-			createdDate = &parsed
-		}
-		
-		project, err := pane.repo.CreateWithJiraAndDate(epic.Title, epic.Key, createdDate)
+		// Create new project linked with the epic
+		project, err := syncProjects.CreateWithLink(epic.Title, ticketmanager.EpicLink(provider, epic))
 		if err != nil {
 			continue
 		}
@@ -415,39 +448,21 @@ func (pane *ProjectPane) importEpicsFromTicketManager() {
 		imported++
 	}
 
-	if imported > 0 || updated > 0 {
-		providerName := string(pane.providerType)
-		message := ""
-		if imported > 0 && updated > 0 {
-			message = fmt.Sprintf(
-				"[lime]Imported %d new epics and updated %d existing projects with %s IDs",
-				imported,
-				updated,
-				providerName,
-			)
-		} else if imported > 0 {
-			message = fmt.Sprintf("[lime]Imported %d user-created epics from %s", imported, providerName)
-		} else {
-			message = fmt.Sprintf("[lime]Updated %d existing projects with %s IDs", updated, providerName)
-		}
-		statusBar.showForSeconds(message, 5)
-		pane.loadListItems(true)
-	} else {
-		statusBar.showForSeconds("[yellow]No new user-created epics to import", 3)
-	}
+	return imported, updated
 }
 
 // importTasksForEpic imports tasks for a specific epic
 func (pane *ProjectPane) importTasksForEpic(project model.Project, epicKey string) {
-	if pane.ticketManager == nil {
-		util.LogWarning("No ticket manager available for importing tasks")
+	tm, provider, err := projectTicketManager(project)
+	if err != nil {
+		util.LogWarning("No ticket manager available for importing tasks: %v", err)
 		return
 	}
 
-	util.LogInfo("=== IMPORTING TASKS FOR EPIC %s ===", epicKey)
+	util.LogInfo("=== IMPORTING TASKS FOR %s EPIC %s ===", provider.DisplayName(), epicKey)
 	util.LogInfo("Project: %s (ID: %d)", project.Title, project.ID)
 
-	tasks, err := pane.ticketManager.ListTasksForEpic(epicKey)
+	tasks, err := tm.ListTasksForEpic(epicKey)
 	if err != nil {
 		util.LogError("Failed to get tasks for epic %s: %v", epicKey, err)
 		statusBar.showForSeconds(fmt.Sprintf("[red]Failed to get tasks for %s: %v", epicKey, err), 5)
@@ -455,32 +470,44 @@ func (pane *ProjectPane) importTasksForEpic(project model.Project, epicKey strin
 	}
 
 	util.LogInfo("Found %d tasks in epic %s", len(tasks), epicKey)
-	
+
 	importedCount := 0
 	skippedCount := 0
 	errorCount := 0
 
 	for _, task := range tasks {
 		util.LogInfo("Processing task: %s - %s", task.Key, task.Title)
-		
+
 		// Check if task already exists
-		existing, err := taskRepo.GetByJiraID(task.Key)
+		existing, err := taskRepo.GetByExternalKey(string(provider), task.Key)
 		if err == nil && existing != nil {
 			util.LogInfo("  Task %s already exists, skipping", task.Key)
+			if existing.RemoteStatus != task.Status {
+				syncRemoteStatus(existing, task)
+			}
 			skippedCount++
 			continue
 		}
 
 		// Create task
 		newTask := model.Task{
-			ProjectID: project.ID,
-			Title:     task.Title,
-			Details:   task.Description,
-			Completed: task.Completed,
-			JiraID:    task.Key,
+			ProjectID:    project.ID,
+			Title:        task.Title,
+			Details:      task.Description,
+			Completed:    task.Completed,
+			Status:       task.TaskStatus(),
+			RemoteStatus: task.Status,
+			ExternalLink: ticketmanager.TaskLink(provider, task),
+		}
+		if newTask.RemoteCreatedAt != nil {
+			newTask.CreatedAt = *newTask.RemoteCreatedAt
+		}
+		if newTask.Completed {
+			// Best guess of completion time for tasks completed in the ticket system
+			newTask.CompletedAt = newTask.RemoteUpdatedAt
 		}
 
-		err = taskRepo.CreateTask(&newTask)
+		err = taskRepo.WithSource(model.ActivitySourceSync).CreateTask(&newTask)
 		if err != nil {
 			util.LogError("  Failed to create task %s: %v", task.Key, err)
 			errorCount++
@@ -489,13 +516,13 @@ func (pane *ProjectPane) importTasksForEpic(project model.Project, epicKey strin
 			importedCount++
 		}
 	}
-	
+
 	util.LogInfo("=== TASK IMPORT SUMMARY FOR %s ===", epicKey)
 	util.LogInfo("  Imported: %d tasks", importedCount)
 	util.LogInfo("  Skipped: %d tasks (already exist)", skippedCount)
 	util.LogInfo("  Errors: %d tasks", errorCount)
 	util.LogInfo("=====================================")
-	
+
 	// Show status message
 	if importedCount > 0 {
 		statusBar.showForSeconds(fmt.Sprintf("[lime]Imported %d tasks for %s", importedCount, epicKey), 3)
@@ -506,16 +533,56 @@ func (pane *ProjectPane) importTasksForEpic(project model.Project, epicKey strin
 	}
 }
 
-// findProjectByJiraID finds a project by its JIRA ID
-func (pane *ProjectPane) findProjectByJiraID(jiraID string) *model.Project {
+// syncRemoteStatus updates status of a task when status of its ticket is changed in ticket provider
+func syncRemoteStatus(task *model.Task, ticket ticketmanager.Task) {
+	updated := *task
+	updated.Status = ticket.TaskStatus()
+	updated.RemoteStatus = ticket.Status
+	updated.Completed = model.IsClosedStatus(updated.Status)
+	if err := taskRepo.WithSource(model.ActivitySourceSync).Update(&updated); err != nil {
+		util.LogError("  Failed to update status of task %s: %v", ticket.Key, err)
+		return
+	}
+	util.LogInfo("  Status of task %s changed to %s (%s)", ticket.Key, updated.Status, ticket.Status)
+}
+
+// findProjectByExternalKey finds a project by its provider and ticket key
+func (pane *ProjectPane) findProjectByExternalKey(provider ticketmanager.ProviderType, key string) *model.Project {
 	for i := range pane.projects {
-		if pane.projects[i].Jira == jiraID {
+		if pane.projects[i].ExternalKey == key && tickets.Resolve(pane.projects[i].Provider) == provider {
 			return &pane.projects[i]
 		}
 	}
 	return nil
 }
 
+// isEpicInTrash checks if the project of an epic is deleted
+func isEpicInTrash(provider ticketmanager.ProviderType, key string, deletedProjects []model.Project) bool {
+	for _, project := range deletedProjects {
+		if project.ExternalKey == key && tickets.Resolve(project.Provider) == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshProject shows changes of a project (e.g. renamed) in the list, header and detail pane
+func (pane *ProjectPane) refreshProject(project model.Project) {
+	for i := range pane.projects {
+		if pane.projects[i].ID == project.ID {
+			pane.projects[i] = project
+			pane.list.SetItemText(pane.projectListStarting+i, "- "+project.GetTitle(), "")
+		}
+	}
+
+	if pane.activeProject != nil && pane.activeProject.ID == project.ID {
+		*pane.activeProject = project
+		taskPane.listTitle = project.Title
+		projectDetailPane.SetProject(pane.activeProject)
+		updateProjectHeader()
+	}
+}
+
 // findProjectByTitle finds a project by its title
 func (pane *ProjectPane) findProjectByTitle(title string) *model.Project {
 	for i := range pane.projects {
@@ -526,15 +593,11 @@ func (pane *ProjectPane) findProjectByTitle(title string) *model.Project {
 	return nil
 }
 
-// cleanupAndRelinkProjects removes duplicate projects and links existing projects to ticket manager
+// cleanupAndRelinkProjects removes duplicate projects and links existing projects to ticket managers
 func (pane *ProjectPane) cleanupAndRelinkProjects() {
-	if pane.ticketManager == nil {
-		providerName := string(pane.providerType)
+	if !tickets.IsConfigured() {
 		statusBar.showForSeconds(
-			fmt.Sprintf(
-				"[red]%s not configured. Set required environment variables.",
-				providerName,
-			),
+			"[red]No ticket provider configured. Set required environment variables.",
 			8,
 		)
 		return
@@ -542,73 +605,76 @@ func (pane *ProjectPane) cleanupAndRelinkProjects() {
 
 	// Reload projects to ensure we have the latest data
 	var err error
-	pane.projects, err = pane.repo.GetAllSortedByJiraDate()
+	pane.projects, err = pane.repo.GetAllSortedByRemoteDate()
 	if err != nil {
 		statusBar.showForSeconds("[red]Failed to load projects: "+err.Error(), 5)
 		return
 	}
 
-	epics, err := pane.ticketManager.ListUserEpics()
-	if err != nil {
-		providerName := string(pane.providerType)
-		statusBar.showForSeconds(
-			fmt.Sprintf("[red]Failed to fetch epics from %s: %s", providerName, err.Error()),
-			5,
-		)
-		return
-	}
-
 	linkedCount := 0
 	removedCount := 0
+	var providerNames []string
 
-	for _, epic := range epics {
-		// Find all projects with this epic title
-		var projectsWithTitle []*model.Project
-		var projectWithJira *model.Project
-
-		for i := range pane.projects {
-			if pane.projects[i].Title == epic.Title {
-				if pane.projects[i].Jira == epic.Key {
-					projectWithJira = &pane.projects[i]
-				} else if pane.projects[i].Jira == "" {
-					projectsWithTitle = append(projectsWithTitle, &pane.projects[i])
+	for _, provider := range tickets.Providers() {
+		tm, _ := tickets.Get(string(provider))
+		epics, err := tm.ListUserEpics()
+		if err != nil {
+			statusBar.showForSeconds(
+				fmt.Sprintf("[red]Failed to fetch epics from %s: %s", provider.DisplayName(), err.Error()),
+				5,
+			)
+			return
+		}
+		providerNames = append(providerNames, provider.DisplayName())
+
+		for _, epic := range epics {
+			// Find all projects with this epic title
+			var projectsWithTitle []*model.Project
+			var projectWithJira *model.Project
+
+			for i := range pane.projects {
+				if pane.projects[i].Title == epic.Title {
+					if pane.projects[i].ExternalKey == epic.Key && tickets.Resolve(pane.projects[i].Provider) == provider {
+						projectWithJira = &pane.projects[i]
+					} else if !pane.projects[i].IsLinked() {
+						projectsWithTitle = append(projectsWithTitle, &pane.projects[i])
+					}
 				}
 			}
-		}
 
-		// If we have a project with JIRA ID and projects without, merge them
-		if projectWithJira != nil && len(projectsWithTitle) > 0 {
-			for _, oldProject := range projectsWithTitle {
-				// Move tasks from old project to the JIRA-linked project
-				tasks, err := taskRepo.GetAllByProject(*oldProject)
-				if err == nil {
-					for _, task := range tasks {
-						task.ProjectID = projectWithJira.ID
-						_ = taskRepo.Update(&task)
+			// If we have a project with ticket ID and projects without, merge them
+			if projectWithJira != nil && len(projectsWithTitle) > 0 {
+				for _, oldProject := range projectsWithTitle {
+					// Move tasks from old project to the ticket-linked project
+					tasks, err := taskRepo.GetAllByProject(*oldProject)
+					if err == nil {
+						for _, task := range tasks {
+							task.ProjectID = projectWithJira.ID
+							_ = taskRepo.WithSource(model.ActivitySourceSync).Update(&task)
+						}
 					}
+					// Remove the old project
+					_ = pane.repo.Delete(oldProject)
+					removedCount++
+				}
+			} else if len(projectsWithTitle) == 1 && projectWithJira == nil {
+				// Link the existing project to the provider
+				projectsWithTitle[0].ExternalLink = ticketmanager.EpicLink(provider, epic)
+				err := pane.repo.Update(projectsWithTitle[0])
+				if err == nil {
+					// Import tasks for this epic
+					pane.importTasksForEpic(*projectsWithTitle[0], epic.Key)
+					linkedCount++
 				}
-				// Remove the old project
-				_ = pane.repo.Delete(oldProject)
-				removedCount++
-			}
-		} else if len(projectsWithTitle) == 1 && projectWithJira == nil {
-			// Link the existing project to JIRA
-			projectsWithTitle[0].Jira = epic.Key
-			err := pane.repo.Update(projectsWithTitle[0])
-			if err == nil {
-				// Import tasks for this epic
-				pane.importTasksForEpic(*projectsWithTitle[0], epic.Key)
-				linkedCount++
 			}
 		}
 	}
 
-	providerName := string(pane.providerType)
 	statusBar.showForSeconds(
 		fmt.Sprintf(
 			"[lime]Linked %d projects to %s, removed %d duplicates",
 			linkedCount,
-			providerName,
+			strings.Join(providerNames, ", "),
 			removedCount,
 		),
 		5,
@@ -618,34 +684,33 @@ func (pane *ProjectPane) cleanupAndRelinkProjects() {
 
 // forceRefreshTasks forces a refresh of tasks for the currently selected project
 func (pane *ProjectPane) forceRefreshTasks() {
-	if pane.ticketManager == nil {
-		providerName := string(pane.providerType)
-		statusBar.showForSeconds(fmt.Sprintf("[red]%s not configured", providerName), 3)
-		return
-	}
-
 	selectedIndex := pane.list.GetCurrentItem()
 	projectindex := selectedIndex - pane.projectListStarting
 	if projectindex >= 0 && projectindex < len(pane.projects) {
 		project := pane.projects[projectindex]
-		if project.Jira == "" {
+		if !project.IsLinked() {
 			statusBar.showForSeconds("[yellow]Project has no ticket associated", 3)
 			return
 		}
 
-		providerName := string(pane.providerType)
+		_, provider, err := projectTicketManager(project)
+		if err != nil {
+			statusBar.showForSeconds("[red]"+err.Error(), 3)
+			return
+		}
+
 		statusBar.showForSeconds(
-			fmt.Sprintf("[yellow]Refreshing tasks from %s...", providerName),
+			fmt.Sprintf("[yellow]Refreshing tasks from %s...", provider.DisplayName()),
 			2,
 		)
-		pane.importTasksForEpic(project, project.Jira)
+		pane.importTasksForEpic(project, project.ExternalKey)
 
 		// If this is the active project, reload its tasks
 		if pane.activeProject != nil && pane.activeProject.ID == project.ID {
 			taskPane.LoadProjectTasks(*pane.activeProject)
 		}
 
-		statusBar.showForSeconds(fmt.Sprintf("[lime]Tasks refreshed from %s", providerName), 3)
+		statusBar.showForSeconds(fmt.Sprintf("[lime]Tasks refreshed from %s", provider.DisplayName()), 3)
 	} else {
 		statusBar.showForSeconds("[yellow]Select a project first", 3)
 	}
@@ -653,29 +718,28 @@ func (pane *ProjectPane) forceRefreshTasks() {
 
 // fixOrphanedTasks fixes tasks that exist with ticket IDs but wrong ProjectIDs
 func (pane *ProjectPane) fixOrphanedTasks() {
-	if pane.ticketManager == nil {
-		providerName := string(pane.providerType)
-		statusBar.showForSeconds(fmt.Sprintf("[red]%s not configured", providerName), 3)
-		return
-	}
-
 	selectedIndex := pane.list.GetCurrentItem()
 	projectindex := selectedIndex - pane.projectListStarting
 	if projectindex >= 0 && projectindex < len(pane.projects) {
 		project := pane.projects[projectindex]
-		if project.Jira == "" {
+		if !project.IsLinked() {
 			statusBar.showForSeconds("[yellow]Project has no ticket associated", 3)
 			return
 		}
 
+		tm, provider, err := projectTicketManager(project)
+		if err != nil {
+			statusBar.showForSeconds("[red]"+err.Error(), 3)
+			return
+		}
+
 		statusBar.showForSeconds("[yellow]Finding and fixing orphaned tasks...", 2)
 
 		// Get all tasks for this epic from ticket manager
-		tasks, err := pane.ticketManager.ListTasksForEpic(project.Jira)
+		tasks, err := tm.ListTasksForEpic(project.ExternalKey)
 		if err != nil {
-			providerName := string(pane.providerType)
 			statusBar.showForSeconds(
-				fmt.Sprintf("[red]Error getting tasks from %s: %s", providerName, err.Error()),
+				fmt.Sprintf("[red]Error getting tasks from %s: %s", provider.DisplayName(), err.Error()),
 				5,
 			)
 			return
@@ -684,11 +748,11 @@ func (pane *ProjectPane) fixOrphanedTasks() {
 		fixed := 0
 		for _, task := range tasks {
 			// Check if task exists with wrong ProjectID
-			existing, err := taskRepo.GetByJiraID(task.Key)
+			existing, err := taskRepo.GetByExternalKey(string(provider), task.Key)
 			if err == nil && existing != nil && existing.ProjectID != project.ID {
 				// Update the ProjectID
 				existing.ProjectID = project.ID
-				err = taskRepo.Update(existing)
+				err = taskRepo.WithSource(model.ActivitySourceSync).Update(existing)
 				if err == nil {
 					fixed++
 				}
@@ -708,37 +772,3 @@ func (pane *ProjectPane) fixOrphanedTasks() {
 		statusBar.showForSeconds("[yellow]Select a project first", 3)
 	}
 }
-
-// parseJiraDate parses JIRA's ISO 8601 date format
-func (pane *ProjectPane) parseJiraDate(dateStr string) (time.Time, error) {
-	// JIRA typically returns dates in RFC3339 format like "2023-10-15T14:30:00.000+0000"
-	layouts := []string{
-		time.RFC3339,
-		"2006-01-02T15:04:05.000-0700",
-		"2006-01-02T15:04:05.000+0000",
-		"2006-01-02T15:04:05-0700",
-		"2006-01-02T15:04:05+0000",
-	}
-	
-	for _, layout := range layouts {
-		if t, err := time.Parse(layout, dateStr); err == nil {
-			return t, nil
-		}
-	}
-	
-	return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
-}
-
-// createProjectWithJiraAndDate creates a new project with JIRA ticket ID and creation date
-func (pane *ProjectPane) createProjectWithJiraAndDate(title, jiraKey string) (model.Project, error) {
-	var createdDate *time.Time
-	
-	// Fetch epic details to get creation date
-	if epicDetails, err := pane.ticketManager.DescribeEpic(jiraKey); err == nil {
-		if parsed, err := pane.parseJiraDate(epicDetails.CreatedDate); err == nil {
-			createdDate = &parsed
-		}
-	}
-	
-	return pane.repo.CreateWithJiraAndDate(title, jiraKey, createdDate)
-}
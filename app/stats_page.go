@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/ajaxray/geek-life/stats"
+)
+
+// statsPageDays is the number of days covered in statistics page
+const statsPageDays = 30
+
+// showStatsPage displays statistics of last 30 days in a modal, with sparklines of daily counts
+func showStatsPage() {
+	activePane := app.GetFocus()
+
+	today := stats.StartOfDay(time.Now())
+	report, err := computeStats(stats.Options{From: today.AddDate(0, 0, 1-statsPageDays), To: today})
+	if err != nil {
+		statusBar.showForSeconds("[red]Could not compute statistics: "+err.Error(), 5)
+		return
+	}
+
+	summary := tview.NewTextView().SetDynamicColors(true)
+	summary.SetText(fmt.Sprintf(
+		"[::b]Created:[::-]   %4d  [lightblue]%s[-]\n"+
+			"[::b]Completed:[::-] %4d  [green]%s[-]\n"+
+			"[::b]Overdue:[::-]   %4d\n"+
+			"[::b]Average time to complete:[::-] %.1f days",
+		report.Created, stats.Sparkline(report.CreatedPerDay()),
+		report.Completed, stats.Sparkline(report.CompletedPerDay()),
+		report.Overdue,
+		report.AverageDaysToComplete,
+	))
+
+	weeks := makeStatsTable("Week of", "Created", "Completed")
+	for i, week := range report.Weeks {
+		setStatsRow(weeks, i+1, week.Start.Format(dateLayoutISO), week.Created, week.Completed)
+	}
+
+	projects := makeStatsTable("Project", "Created", "Completed", "Open", "Overdue")
+	for i, project := range report.Projects {
+		setStatsRow(projects, i+1, project.Title, project.Created, project.Completed, project.Open, project.Overdue)
+	}
+
+	page := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(summary, 5, 0, false).
+		AddItem(tview.NewFlex().
+			AddItem(weeks, 0, 1, false).
+			AddItem(projects, 0, 2, true), 0, 1, true)
+	page.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			app.SetRoot(layout, true).EnableMouse(true)
+			app.SetFocus(activePane)
+			return nil
+		}
+		return event
+	})
+
+	showModal(fmt.Sprintf(
+		"Statistics: %s to %s (Esc to close)",
+		report.From.Format(dateLayoutISO),
+		report.To.Format(dateLayoutISO),
+	), page, 0, 0)
+	app.SetFocus(projects)
+}
+
+func makeStatsTable(headers ...string) *tview.Table {
+	table := tview.NewTable().SetFixed(1, 0).SetSelectable(true, false)
+	for col, header := range headers {
+		table.SetCell(0, col, tview.NewTableCell(strings.ToUpper(header)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+	table.SetBorder(true)
+
+	return table
+}
+
+func setStatsRow(table *tview.Table, row int, label string, counts ...int) {
+	table.SetCell(row, 0, tview.NewTableCell(label).SetExpansion(1))
+	for i, count := range counts {
+		table.SetCell(row, i+1, tview.NewTableCell(fmt.Sprint(count)).SetAlign(tview.AlignRight))
+	}
+}
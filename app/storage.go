@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	homedir "github.com/mitchellh/go-homedir"
+
+	"github.com/ajaxray/geek-life/repository/markdown"
+	repo "github.com/ajaxray/geek-life/repository/storm"
+	"github.com/ajaxray/geek-life/ticketmanager"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// Storage backends, chosen by STORAGE_BACKEND
+const (
+	storageBolt     = "bolt"
+	storageMarkdown = "markdown"
+)
+
+// openStorage connects the repositories with the storage backend. Returns a function to close it.
+// The bolt database (db) is not opened with other backends.
+func openStorage() func() {
+	tickets = ticketmanager.NewRegistry()
+
+	switch backend := util.GetEnvStr("STORAGE_BACKEND", storageBolt); backend {
+	case storageMarkdown:
+		store, err := markdown.Open(markdownDir())
+		util.FatalIfError(err, "Could not open Markdown directory")
+
+		projectRepo = markdown.NewProjectRepository(store)
+		taskRepo = markdown.NewTaskRepository(store)
+		activityRepo = markdown.NewActivityRepository(store)
+		return func() {}
+	case storageBolt:
+		db = util.ConnectStorm(dbFile)
+		projectRepo = repo.NewProjectRepository(db)
+		taskRepo = repo.NewTaskRepository(db)
+		activityRepo = repo.NewActivityRepository(db)
+		return func() {
+			if err := db.Close(); err != nil {
+				util.LogIfError(err, "Error in closing storm Db")
+			}
+		}
+	default:
+		util.FatalIfError(fmt.Errorf("unknown STORAGE_BACKEND %q", backend), "Use %s or %s", storageBolt, storageMarkdown)
+		return nil
+	}
+}
+
+// markdownDir is the directory of Markdown storage. MARKDOWN_DIR overrides default ~/.geek-life/notes
+func markdownDir() string {
+	dir, err := homedir.Expand(util.GetEnvStr("MARKDOWN_DIR", filepath.Join(util.AppDir(), "notes")))
+	util.FatalIfError(err, "Invalid MARKDOWN_DIR")
+
+	return dir
+}
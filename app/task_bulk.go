@@ -0,0 +1,441 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/ajaxray/geek-life/export"
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/remind"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// toggleMark selects (or unselects) the current task for bulk actions, and goes to the next one
+func (pane *TaskPane) toggleMark() {
+	index := pane.list.GetCurrentItem()
+	if index < 0 || index >= len(pane.tasks) {
+		return
+	}
+
+	id := pane.tasks[index].ID
+	if pane.marked == nil {
+		pane.marked = make(map[int64]bool)
+	}
+	if pane.marked[id] {
+		delete(pane.marked, id)
+	} else {
+		pane.marked[id] = true
+	}
+
+	pane.list.SetItemText(index, pane.listingTitle(index), "")
+	pane.list.SetCurrentItem(index + 1)
+	pane.showMarkedCount()
+}
+
+// toggleMarkAll selects all tasks of the list, or none if all are selected already
+func (pane *TaskPane) toggleMarkAll() {
+	if len(pane.tasks) == 0 {
+		return
+	}
+
+	if len(pane.marked) == len(pane.tasks) {
+		pane.marked = nil
+	} else {
+		pane.marked = make(map[int64]bool, len(pane.tasks))
+		for _, task := range pane.tasks {
+			pane.marked[task.ID] = true
+		}
+	}
+
+	pane.refreshListing()
+	pane.showMarkedCount()
+}
+
+// clearMarks unselects all tasks
+func (pane *TaskPane) clearMarks() {
+	pane.marked = nil
+	pane.refreshListing()
+	statusBar.showForSeconds("[yellow]Selection cleared", 3)
+}
+
+// refreshListing updates the text of all list items, e.g. after tasks are changed or marked
+func (pane *TaskPane) refreshListing() {
+	for i := range pane.tasks {
+		pane.list.SetItemText(i, pane.listingTitle(i), "")
+	}
+}
+
+func (pane *TaskPane) showMarkedCount() {
+	if len(pane.marked) == 0 {
+		statusBar.showForSeconds("[yellow]No task selected", 3)
+		return
+	}
+	statusBar.showForSeconds(
+		fmt.Sprintf("[yellow]%d tasks selected. Press a for bulk actions, Esc to clear selection.", len(pane.marked)),
+		5,
+	)
+}
+
+// markedTasks are pointers to the selected tasks of the list, in listing order
+func (pane *TaskPane) markedTasks() []*model.Task {
+	var tasks []*model.Task
+	for i := range pane.tasks {
+		if pane.marked[pane.tasks[i].ID] {
+			tasks = append(tasks, &pane.tasks[i])
+		}
+	}
+	return tasks
+}
+
+// bulkResult counts the outcome of a bulk action, to be reported in status bar
+type bulkResult struct {
+	done, skipped, failed int
+	firstErr              error
+}
+
+func (r *bulkResult) add(err error) {
+	if err == nil {
+		r.done++
+		return
+	}
+
+	r.failed++
+	if r.firstErr == nil {
+		r.firstErr = err
+	}
+}
+
+// report shows the result in status bar, e.g. "Moved 3 tasks to Work" for verb "Moved" and suffix " to Work"
+func (r bulkResult) report(verb, suffix string) {
+	message := fmt.Sprintf("%s %d tasks%s", verb, r.done, suffix)
+	if r.skipped > 0 {
+		message += fmt.Sprintf(", %d skipped", r.skipped)
+	}
+	if r.failed == 0 {
+		statusBar.showForSeconds("[lime]"+message, 5)
+		return
+	}
+
+	util.LogError("Bulk action %q failed for %d tasks: %v", verb, r.failed, r.firstErr)
+	statusBar.showForSeconds(fmt.Sprintf("[red]%s, %d failed: %s", message, r.failed, r.firstErr.Error()), 10)
+}
+
+// showBulkActions lists the actions that can be applied to selected tasks
+func (pane *TaskPane) showBulkActions() {
+	tasks := pane.markedTasks()
+	if len(tasks) == 0 {
+		statusBar.showForSeconds("[yellow]Select tasks first (v or Space, Ctrl+A for all)", 5)
+		return
+	}
+
+	activePane := app.GetFocus()
+	closeMenu := func() {
+		app.SetRoot(layout, true).EnableMouse(true)
+		app.SetFocus(activePane)
+	}
+
+	menu := tview.NewList().ShowSecondaryText(false)
+
+	actions := []struct {
+		shortcut rune
+		label    string
+		action   func()
+	}{
+		{'c', "Complete", func() { pane.bulkSetCompleted(tasks, true) }},
+		{'r', "Reopen", func() { pane.bulkSetCompleted(tasks, false) }},
+		{'s', "Set status", func() { pane.bulkSetStatus(tasks) }},
+		{'d', "Set due date", func() { pane.bulkSetDueDate(tasks) }},
+		{'w', "Set start date", func() { pane.bulkSetStartDate(tasks) }},
+		{'n', "Set reminders", func() { pane.bulkSetReminders(tasks) }},
+		{'m', "Move to project", func() { pane.bulkMove(tasks) }},
+		// Not t, as it's the global shortcut for Tasks pane
+		{'a', "Add or remove tags", func() { pane.bulkTag(tasks) }},
+		{'j', "Push to ticket provider", func() { pane.bulkPushToTickets(tasks) }},
+		{'e', "Export", func() { pane.bulkExport(tasks) }},
+		{'x', "Delete (move to Trash)", func() { pane.bulkDelete(tasks) }},
+	}
+	for _, item := range actions {
+		menu.AddItem(item.label, "", item.shortcut, func(action func()) func() {
+			return func() {
+				closeMenu()
+				action()
+			}
+		}(item.action))
+	}
+
+	menu.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			closeMenu()
+			return nil
+		}
+		return event
+	})
+
+	showModal(fmt.Sprintf("%d selected tasks (Esc to close)", len(tasks)), menu, 40, len(actions)+2)
+	app.SetFocus(menu)
+}
+
+func (pane *TaskPane) bulkSetCompleted(tasks []*model.Task, completed bool) {
+	var result bulkResult
+	for _, task := range tasks {
+		if task.Completed == completed {
+			result.skipped++
+			continue
+		}
+
+		err := pane.taskRepo.UpdateField(task, "Completed", completed)
+		if err == nil {
+			if ticketErr := syncTicketStatus(*task); ticketErr != nil {
+				err = fmt.Errorf("%s: could not update ticket: %w", task.ExternalKey, ticketErr)
+			}
+		}
+		result.add(err)
+	}
+
+	pane.refreshListing()
+	if completed {
+		result.report("Completed", "")
+	} else {
+		result.report("Reopened", "")
+	}
+}
+
+func (pane *TaskPane) bulkSetStatus(tasks []*model.Task) {
+	showStatusPicker("", func(status string) {
+		var result bulkResult
+		for _, task := range tasks {
+			if task.CurrentStatus() == status {
+				result.skipped++
+				continue
+			}
+
+			change, err := setTaskStatus(task, status)
+			if err == nil && change.ticketErr != nil {
+				err = fmt.Errorf("%s: %w", task.ExternalKey, change.ticketErr)
+			}
+			result.add(err)
+		}
+
+		pane.refreshListing()
+		result.report("Changed status of", " to "+status)
+	})
+}
+
+func (pane *TaskPane) bulkSetDueDate(tasks []*model.Task) {
+	showInputPrompt("Due date of selected tasks", "Due (e.g. fri, fri 3pm, dec 3, empty to unset): ", "", func(text string) {
+		due, err := util.ParseDateTime(text, time.Now())
+		if err != nil {
+			statusBar.showForSeconds("[red]"+err.Error(), 8)
+			return
+		}
+
+		var result bulkResult
+		for _, task := range tasks {
+			result.add(updateTaskDue(task, due))
+		}
+
+		pane.refreshListing()
+		result.report("Set due date of", "")
+	})
+}
+
+func (pane *TaskPane) bulkSetStartDate(tasks []*model.Task) {
+	showInputPrompt("Start date of selected tasks", "Start (e.g. mon, 2w, dec 3, empty to unset): ", "", func(text string) {
+		day, err := util.ParseDate(text, time.Now())
+		if err != nil {
+			statusBar.showForSeconds("[red]"+err.Error(), 8)
+			return
+		}
+
+		var result bulkResult
+		for _, task := range tasks {
+			result.add(updateTaskStart(task, day))
+		}
+
+		pane.refreshListing()
+		result.report("Set start date of", "")
+	})
+}
+
+func (pane *TaskPane) bulkSetReminders(tasks []*model.Task) {
+	showInputPrompt("Reminders of selected tasks", "Remind (e.g. 15m, 1d, at due, fri 9am, empty to unset): ", "", func(text string) {
+		reminders, err := parseReminders(text, time.Now(), remind.ConfigFromEnv().DayStart)
+		if err != nil {
+			statusBar.showForSeconds("[red]"+err.Error(), 8)
+			return
+		}
+
+		var result bulkResult
+		for _, task := range tasks {
+			result.add(updateTaskReminders(task, reminders))
+		}
+
+		pane.refreshListing()
+		result.report("Set reminders of", "")
+	})
+}
+
+func (pane *TaskPane) bulkMove(tasks []*model.Task) {
+	var currentID int64
+	if project := projectPane.GetActiveProject(); project != nil {
+		currentID = project.ID
+	}
+
+	showProjectPicker(fmt.Sprintf("Move %d tasks to project", len(tasks)), currentID, func(project model.Project) {
+		var result bulkResult
+		var moved []model.Task
+		for _, task := range tasks {
+			if task.ProjectID == project.ID {
+				result.skipped++
+				continue
+			}
+
+			ticketErr, err := relocateTask(task, project)
+			if err == nil {
+				moved = append(moved, *task)
+				err = ticketErr
+			}
+			result.add(err)
+		}
+
+		// Tasks are moved out of the list only now, as it invalidates pointers to them
+		for _, task := range moved {
+			pane.refreshMovedTask(task)
+		}
+		result.report("Moved", " to "+project.Title)
+	})
+}
+
+func (pane *TaskPane) bulkTag(tasks []*model.Task) {
+	showInputPrompt("Tag selected tasks", "Tags (comma separated, -tag to remove): ", "", func(text string) {
+		var add, remove []string
+		for _, tag := range strings.Split(text, ",") {
+			tag = strings.TrimSpace(tag)
+			if strings.HasPrefix(tag, "-") {
+				remove = append(remove, strings.TrimSpace(tag[1:]))
+			} else if tag != "" {
+				add = append(add, tag)
+			}
+		}
+		if len(add) == 0 && len(remove) == 0 {
+			return
+		}
+
+		var result bulkResult
+		for _, task := range tasks {
+			var tags []string
+			for _, tag := range task.Tags {
+				if !util.InArray(tag, remove) {
+					tags = append(tags, tag)
+				}
+			}
+			for _, tag := range add {
+				if !util.InArray(tag, tags) {
+					tags = append(tags, tag)
+				}
+			}
+			if strings.Join(tags, ",") == strings.Join(task.Tags, ",") {
+				result.skipped++
+				continue
+			}
+
+			result.add(pane.taskRepo.UpdateField(task, "Tags", tags))
+		}
+
+		result.report("Tagged", "")
+	})
+}
+
+func (pane *TaskPane) bulkPushToTickets(tasks []*model.Task) {
+	if !tickets.IsConfigured() {
+		statusBar.showForSeconds("[red]No ticket provider configured. Set required environment variables.", 8)
+		return
+	}
+
+	var result bulkResult
+	for _, task := range tasks {
+		if task.IsLinked() {
+			result.skipped++
+			continue
+		}
+
+		_, err := pushTaskToTicket(task)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", task.Title, err)
+		}
+		result.add(err)
+	}
+
+	pane.refreshListing()
+	result.report("Created tickets of", "")
+}
+
+func (pane *TaskPane) bulkExport(tasks []*model.Task) {
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		statusBar.showForSeconds("[red]Could not load projects: "+err.Error(), 5)
+		return
+	}
+
+	selected := make([]model.Task, len(tasks))
+	for i, task := range tasks {
+		selected[i] = *task
+	}
+	showExportPicker(export.NewDocument(pane.listTitle+" (selected)", selected, projects))
+}
+
+func (pane *TaskPane) bulkDelete(tasks []*model.Task) {
+	AskYesNo(fmt.Sprintf("Move %d selected tasks to Trash?", len(tasks)), func() {
+		deletedAt := time.Now()
+		var result bulkResult
+		var deleted []model.Task
+		removed := make(map[int64]bool, len(tasks))
+		for _, task := range tasks {
+			err := pane.taskRepo.SoftDelete(task, deletedAt)
+			if err == nil {
+				deleted = append(deleted, *task)
+				removed[task.ID] = true
+			}
+			result.add(err)
+		}
+
+		if len(deleted) > 0 {
+			pushUndo(fmt.Sprintf("deleting %d tasks", len(deleted)), func() error {
+				for i := range deleted {
+					if err := taskRepo.Restore(&deleted[i]); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+
+		pane.removeTasks(removed)
+		result.report("Moved", " to Trash. Press u to undo")
+	})
+}
+
+// showInputPrompt asks for a line of text, onDone gets it when submitted with Enter
+func showInputPrompt(title, label, text string, onDone func(text string)) {
+	activePane := app.GetFocus()
+	closePrompt := func() {
+		app.SetRoot(layout, true).EnableMouse(true)
+		app.SetFocus(activePane)
+	}
+
+	input := makeLightTextInput("").SetLabel(label).SetText(text)
+	input.SetDoneFunc(func(key tcell.Key) {
+		closePrompt()
+		if key == tcell.KeyEnter {
+			onDone(input.GetText())
+		}
+	})
+
+	form := tview.NewFlex().SetDirection(tview.FlexRow).AddItem(input, 1, 0, true)
+	showModal(title+" (Enter = save, Esc = cancel)", form, 80, 3)
+	app.SetFocus(input)
+}
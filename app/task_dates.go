@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// formatDue describes when a task is due, in local time. For tasks due at a time of another time zone,
+// the time there is shown too, e.g. "30 Oct, Friday 09:00 (15:00 Europe/Berlin)".
+func formatDue(task model.Task) string {
+	if task.DueDate == 0 {
+		return ""
+	}
+	if task.DueTime == "" {
+		return task.DueDay(time.Local).Format(dateLayoutHuman)
+	}
+
+	due := task.DueAt(time.Local)
+	text := due.In(time.Local).Format(dateLayoutHuman + " 15:04")
+	if _, offset := due.Zone(); offset != localOffset(due) {
+		text += fmt.Sprintf(" (%s %s)", task.DueTime, task.TimeZone)
+	}
+
+	return text
+}
+
+// localOffset is the offset of local time zone at the moment t
+func localOffset(t time.Time) int {
+	_, offset := t.In(time.Local).Zone()
+	return offset
+}
+
+// formatDueTime is the local time a task is due at, e.g. 15:30. Empty for tasks due any time of the day.
+func formatDueTime(task model.Task) string {
+	if task.DueDate == 0 || task.DueTime == "" {
+		return ""
+	}
+
+	return task.DueAt(time.Local).In(time.Local).Format("15:04")
+}
+
+// setTaskDue sets due date, time and time zone of a task as written by user, without saving.
+// A time without time zone is of the local one, while a date alone keeps the zone of the task.
+func setTaskDue(task *model.Task, due util.DateTime) {
+	switch {
+	case due.TimeZone != "":
+		task.SetTimeZone(due.TimeZone)
+	case due.Clock != "":
+		task.SetTimeZone(util.LocalZoneName())
+	}
+
+	task.SetDueDay(due.Date)
+	if task.DueDate != 0 {
+		task.DueTime = due.Clock
+	}
+}
+
+// updateTaskDue sets due date, time and time zone of a task (see setTaskDue) and saves it
+func updateTaskDue(task *model.Task, due util.DateTime) error {
+	updated := *task
+	setTaskDue(&updated, due)
+	if err := taskRepo.Update(&updated); err != nil {
+		return err
+	}
+
+	*task = updated
+	return nil
+}
+
+// updateTaskStart sets the day a task starts (zero to unset) and saves it
+func updateTaskStart(task *model.Task, day time.Time) error {
+	updated := *task
+	updated.SetStartDay(day)
+	if err := taskRepo.Update(&updated); err != nil {
+		return err
+	}
+
+	*task = updated
+	return nil
+}
+
+// promptTaskStart asks for the day a task starts, until which it's hidden from project and available lists
+func promptTaskStart(task *model.Task, onChange func()) {
+	if task == nil || task.IsDeleted() {
+		return
+	}
+
+	current := ""
+	if task.StartDate != 0 {
+		current = task.StartDay(time.Local).Format(dateLayoutISO)
+	}
+
+	showInputPrompt("Start date (hidden until then)", "Start (e.g. mon, 2w, dec 3, none): ", current, func(text string) {
+		day, err := util.ParseDate(text, time.Now())
+		if err != nil {
+			statusBar.showForSeconds("[red]"+err.Error(), 8)
+			return
+		}
+		if err := updateTaskStart(task, day); err != nil {
+			statusBar.showForSeconds("[red]Could not set start date: "+err.Error(), 5)
+			return
+		}
+
+		onChange()
+		if day.IsZero() {
+			statusBar.showForSeconds("[lime]Start date removed", 3)
+		} else {
+			statusBar.showForSeconds("[lime]Task starts on "+day.Format(dateLayoutHuman), 3)
+		}
+	})
+}
+
+// startedTasks keeps the tasks that have started by now, i.e. without start date or with one passed.
+// It also tells how many are left out.
+func startedTasks(tasks []model.Task, now time.Time) ([]model.Task, int) {
+	started := make([]model.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.IsStarted(now) {
+			started = append(started, task)
+		}
+	}
+
+	return started, len(tasks) - len(started)
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -9,12 +8,12 @@ import (
 	"time"
 	"unicode"
 
-	"github.com/atotto/clipboard"
 	"github.com/gdamore/tcell/v2"
 	"github.com/pgavlin/femto"
 	"github.com/pgavlin/femto/runtime"
 	"github.com/rivo/tview"
 
+	"github.com/ajaxray/geek-life/export"
 	"github.com/ajaxray/geek-life/model"
 	"github.com/ajaxray/geek-life/repository"
 	"github.com/ajaxray/geek-life/ticketmanager"
@@ -31,6 +30,9 @@ type TaskDetailPane struct {
 	*tview.Flex
 	header           *TaskDetailHeader
 	taskDateDisplay  *tview.TextView
+	taskStatusText   *tview.TextView
+	taskStartText    *tview.TextView
+	taskRemindText   *tview.TextView
 	editorHint       *tview.TextView
 	taskDate         *tview.InputField
 	taskStatusToggle *tview.Button
@@ -38,8 +40,6 @@ type TaskDetailPane struct {
 	colorScheme      femto.Colorscheme
 	taskRepo         repository.TaskRepository
 	task             *model.Task
-	ticketManager    ticketmanager.TicketManager
-	providerType     ticketmanager.ProviderType
 }
 
 // NewTaskDetailPane initializes and configures a TaskDetailPane
@@ -48,15 +48,11 @@ func NewTaskDetailPane(taskRepo repository.TaskRepository) *TaskDetailPane {
 		Flex:             tview.NewFlex().SetDirection(tview.FlexRow),
 		header:           NewTaskDetailHeader(taskRepo),
 		taskDateDisplay:  tview.NewTextView().SetDynamicColors(true),
+		taskStatusText:   tview.NewTextView().SetDynamicColors(true),
+		taskStartText:    tview.NewTextView().SetDynamicColors(true),
+		taskRemindText:   tview.NewTextView().SetDynamicColors(true),
 		taskStatusToggle: makeButton("Complete", nil).SetLabelColor(tcell.ColorLightGray),
 		taskRepo:         taskRepo,
-		providerType:     ticketmanager.GetProviderType(),
-	}
-
-	if ticketmanager.IsAnyProviderConfigured() {
-		if tm, err := ticketmanager.NewTicketManager(); err == nil {
-			pane.ticketManager = tm
-		}
 	}
 
 	pane.prepareDetailsEditor()
@@ -82,6 +78,10 @@ func NewTaskDetailPane(taskRepo repository.TaskRepository) *TaskDetailPane {
 		AddItem(pane.header, 4, 1, true).
 		AddItem(blankCell, 1, 1, false).
 		AddItem(pane.makeDateRow(), 1, 1, true).
+		AddItem(pane.makeStartRow(), 1, 1, false).
+		AddItem(pane.makeRemindRow(), 1, 1, false).
+		AddItem(blankCell, 1, 1, false).
+		AddItem(pane.makeStatusRow(), 1, 1, false).
 		AddItem(blankCell, 1, 1, false).
 		AddItem(editorLabel, 1, 1, false).
 		AddItem(pane.taskDetailView, 15, 4, false).
@@ -95,39 +95,36 @@ func NewTaskDetailPane(taskRepo repository.TaskRepository) *TaskDetailPane {
 	return &pane
 }
 
+// Export lets to pick a format and copies the task in that format to clipboard
 func (td *TaskDetailPane) Export() {
-	var content bytes.Buffer
-
-	content.WriteString("# " + td.task.Title + " \n")
-	if td.taskDate.GetText() != "" {
-		content.WriteString("\n> Due Date: " + td.taskDate.GetText() + " \n")
-	}
-	content.WriteString("\n" + td.task.Details + " \n")
-
-	_ = clipboard.WriteAll(content.String())
-	app.SetFocus(td)
-	statusBar.showForSeconds("Task copied. Try Pasting anywhere.", 5)
+	project, _ := projectRepo.GetByID(td.task.ProjectID)
+	showExportPicker(export.NewTaskDocument(*td.task, project))
 }
 
 func (td *TaskDetailPane) makeDateRow() *tview.Flex {
-	td.taskDate = makeLightTextInput("yyyy-mm-dd").
+	td.taskDate = makeLightTextInput("fri 3pm, dec 3").
 		SetLabel("Set:").
 		SetLabelColor(tcell.ColorWhiteSmoke).
-		SetFieldWidth(12).
+		SetFieldWidth(16).
+		SetChangedFunc(td.previewTaskDate).
 		SetDoneFunc(func(key tcell.Key) {
 			switch key {
 			case tcell.KeyEnter:
-				date := parseDateInputOrCurrent(td.taskDate.GetText())
-				td.setTaskDate(date.Unix(), true)
+				due, err := util.ParseDateTime(td.taskDate.GetText(), time.Now())
+				if err != nil {
+					statusBar.showForSeconds("[red]"+err.Error(), 8)
+					return
+				}
+				td.setTaskDue(due)
 			case tcell.KeyEsc:
-				td.setTaskDate(td.task.DueDate, false)
+				td.showTaskDue()
 			}
 			app.SetFocus(td)
 		})
 
 	return tview.NewFlex().
 		AddItem(td.taskDateDisplay, 0, 2, true).
-		AddItem(td.taskDate, 14, 0, true).
+		AddItem(td.taskDate, 20, 0, true).
 		AddItem(blankCell, 1, 0, false).
 		AddItem(makeButton("t[::u]o[::-]day", td.todaySelector), 8, 1, false).
 		AddItem(blankCell, 1, 0, false).
@@ -136,6 +133,24 @@ func (td *TaskDetailPane) makeDateRow() *tview.Flex {
 		AddItem(makeButton("[::u]-[::-]1", td.prevDaySelector), 4, 1, false)
 }
 
+func (td *TaskDetailPane) makeStartRow() *tview.Flex {
+	return tview.NewFlex().
+		AddItem(td.taskStartText, 0, 1, false).
+		AddItem(makeButton("[::u]w[::-]ait", td.promptStart), 6, 0, false)
+}
+
+func (td *TaskDetailPane) makeRemindRow() *tview.Flex {
+	return tview.NewFlex().
+		AddItem(td.taskRemindText, 0, 1, false).
+		AddItem(makeButton("[::u]a[::-]larm", td.promptReminders), 7, 0, false)
+}
+
+func (td *TaskDetailPane) makeStatusRow() *tview.Flex {
+	return tview.NewFlex().
+		AddItem(td.taskStatusText, 0, 1, false).
+		AddItem(makeButton("[::u]s[::-]tatus", func() { promptTaskStatus(td.task) }), 8, 0, false)
+}
+
 func (td *TaskDetailPane) updateToggleDisplay() {
 	if td.task.Completed {
 		td.taskStatusToggle.SetLabel("Resume").SetBackgroundColor(tcell.ColorMaroon)
@@ -147,53 +162,124 @@ func (td *TaskDetailPane) updateToggleDisplay() {
 func (td *TaskDetailPane) toggleTaskStatus() {
 	status := !td.task.Completed
 	if taskRepo.UpdateField(td.task, "Completed", status) == nil {
-		if td.task.JiraID != "" && td.ticketManager != nil {
-			err := td.ticketManager.UpdateTask(
-				td.task.Title,
-				td.task.Details,
-				status,
-				td.task.JiraID,
-			)
-			if err != nil {
-				providerName := string(td.providerType)
+		if td.task.IsLinked() {
+			provider := ticketmanager.ProviderType(td.task.Provider)
+			if err := syncTicketStatus(*td.task); err != nil {
 				statusBar.showForSeconds(
-					fmt.Sprintf("[red]Failed to update %s task: %s", providerName, err.Error()),
+					fmt.Sprintf("[red]Failed to update %s task: %s", provider.DisplayName(), err.Error()),
 					5,
 				)
 			} else {
-				providerName := string(td.providerType)
-				statusBar.showForSeconds(fmt.Sprintf("[lime]%s task updated", providerName), 3)
+				statusBar.showForSeconds(fmt.Sprintf("[lime]%s task updated", provider.DisplayName()), 3)
 			}
 		}
-		td.task.Completed = status
 		taskPane.ReloadCurrentTask()
 	}
 }
 
-// Display Task date in detail pane, and update date if asked to
-func (td *TaskDetailPane) setTaskDate(unixDate int64, update bool) {
-	if update {
-		td.task.DueDate = unixDate
-		if err := td.taskRepo.UpdateField(td.task, "DueDate", unixDate); err != nil {
-			statusBar.showForSeconds("Could not update due date: "+err.Error(), 5)
-			return
-		}
+// syncTicketStatus updates the linked ticket of a task as completed or not.
+// Tasks not linked, or of a ticket manager not configured, are skipped.
+func syncTicketStatus(task model.Task) error {
+	if !task.IsLinked() {
+		return nil
 	}
 
-	if unixDate != 0 {
-		due := time.Unix(unixDate, 0)
-		color := "white"
-		humanDate := due.Format(dateLayoutHuman)
+	project, _ := projectRepo.GetByID(task.ProjectID)
+	tm, _, err := projectTicketManager(project)
+	if err != nil {
+		return nil
+	}
+
+	return tm.UpdateTask(task.Title, task.Details, task.Completed, task.ExternalKey)
+}
+
+// previewTaskDate shows the due date (and time) being typed in date input, as it will be set
+func (td *TaskDetailPane) previewTaskDate(text string) {
+	if !td.taskDate.HasFocus() {
+		return
+	}
+
+	due, err := util.ParseDateTime(text, time.Now())
+	preview := *td.task
+	setTaskDue(&preview, due)
+	switch {
+	case err != nil:
+		td.taskDateDisplay.SetText("[::u]D[::-]ue: [red]?")
+	case preview.DueDate == 0:
+		td.taskDateDisplay.SetText("[::u]D[::-]ue: [gray]→ Not Set")
+	default:
+		td.taskDateDisplay.SetText("[::u]D[::-]ue: [gray]→ " + formatDue(preview))
+	}
+}
 
-		if due.Before(time.Now()) {
+// setTaskDue sets due date and time of the task and displays it
+func (td *TaskDetailPane) setTaskDue(due util.DateTime) {
+	if err := updateTaskDue(td.task, due); err != nil {
+		statusBar.showForSeconds("Could not update due date: "+err.Error(), 5)
+		return
+	}
+
+	td.showTaskDue()
+	taskPane.refreshListing()
+}
+
+// setTaskDate sets the due day of the task (zero to unset), keeping its due time
+func (td *TaskDetailPane) setTaskDate(day time.Time) {
+	due := util.DateTime{Date: day, Clock: td.task.DueTime}
+	if td.task.DueTime != "" {
+		due.TimeZone = td.task.TimeZone
+	}
+	td.setTaskDue(due)
+}
+
+// showTaskDue displays due date and time of the task, and start date
+func (td *TaskDetailPane) showTaskDue() {
+	now := time.Now()
+	if td.task.DueDate != 0 {
+		color := "white"
+		if td.task.IsOverdue(now) {
 			color = "red"
 		}
-		td.taskDateDisplay.SetText(fmt.Sprintf("[::u]D[::-]ue: [%s]%s", color, humanDate))
-		td.taskDate.SetText(due.Format(dateLayoutISO))
+		td.taskDate.SetText(td.task.DueDay(time.Local).Format(dateLayoutISO))
+		if dueTime := formatDueTime(*td.task); dueTime != "" {
+			td.taskDate.SetText(td.taskDate.GetText() + " " + dueTime)
+		}
+		td.taskDateDisplay.SetText(fmt.Sprintf("[::u]D[::-]ue: [%s]%s", color, formatDue(*td.task)))
 	} else {
 		td.taskDate.SetText("")
 		td.taskDateDisplay.SetText("[::u]D[::-]ue: [::d]Not Set")
 	}
+
+	switch {
+	case td.task.StartDate == 0:
+		td.taskStartText.SetText("Start: [::d]Not Set")
+	case td.task.IsStarted(now):
+		td.taskStartText.SetText("Start: " + td.task.StartDay(time.Local).Format(dateLayoutHuman))
+	default:
+		td.taskStartText.SetText("Start: [yellow]" + td.task.StartDay(time.Local).Format(dateLayoutHuman) + "[-] [::d](hidden till then)")
+	}
+
+	if len(td.task.Reminders) == 0 {
+		td.taskRemindText.SetText("Remind: [::d]None")
+	} else {
+		td.taskRemindText.SetText("Remind: " + formatReminders(*td.task))
+	}
+}
+
+// promptReminders asks for reminders of the task
+func (td *TaskDetailPane) promptReminders() {
+	promptTaskReminders(td.task, func() {
+		td.showTaskDue()
+		taskPane.refreshListing()
+	})
+}
+
+// promptStart asks for the day the task starts
+func (td *TaskDetailPane) promptStart() {
+	promptTaskStart(td.task, func() {
+		td.showTaskDue()
+		taskPane.refreshListing()
+	})
 }
 
 func (td *TaskDetailPane) prepareDetailsEditor() {
@@ -351,6 +437,27 @@ func (td *TaskDetailPane) handleShortcuts(event *tcell.EventKey) *tcell.EventKey
 		case 'x':
 			td.Export()
 			return nil
+		case 'i':
+			showTaskHistory(td.task)
+			return nil
+		case 'l':
+			td.copyTaskLink()
+			return nil
+		case 'm':
+			promptMoveTask(td.task)
+			return nil
+		case 'w':
+			td.promptStart()
+			return nil
+		case 'a':
+			td.promptReminders()
+			return nil
+		case 's':
+			promptTaskStatus(td.task)
+			return nil
+		case 'y':
+			duplicateTask(td.task)
+			return nil
 		case 'o':
 			td.todaySelector()
 			return nil
@@ -374,19 +481,28 @@ func (td *TaskDetailPane) SetTask(task *model.Task) {
 	td.taskDetailView.Buf = makeBufferFromString(td.task.Details)
 	td.taskDetailView.SetColorscheme(td.colorScheme)
 	td.taskDetailView.Start()
-	td.setTaskDate(td.task.DueDate, false)
+	td.showTaskDue()
+	td.taskStatusText.SetText(makeStatusText(*task))
 	td.updateToggleDisplay()
 	td.deactivateEditor()
 }
 
 func (td *TaskDetailPane) todaySelector() {
-	td.setTaskDate(parseDateInputOrCurrent("").Unix(), true)
+	td.setTaskDate(toDate(time.Now()))
 }
 
 func (td *TaskDetailPane) nextDaySelector() {
-	td.setTaskDate(parseDateInputOrCurrent(td.taskDate.GetText()).AddDate(0, 0, 1).Unix(), true)
+	td.setTaskDate(td.dueDayOrToday().AddDate(0, 0, 1))
 }
 
 func (td *TaskDetailPane) prevDaySelector() {
-	td.setTaskDate(parseDateInputOrCurrent(td.taskDate.GetText()).AddDate(0, 0, -1).Unix(), true)
+	td.setTaskDate(td.dueDayOrToday().AddDate(0, 0, -1))
+}
+
+// dueDayOrToday is the day the task is due, or today if not scheduled
+func (td *TaskDetailPane) dueDayOrToday() time.Time {
+	if td.task.DueDate == 0 {
+		return toDate(time.Now())
+	}
+	return td.task.DueDay(time.Local)
 }
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/ajaxray/geek-life/model"
+)
+
+const historyTimeLayout = "2006-01-02 15:04"
+
+// showTaskHistory displays timestamps and change history of a task in a modal
+func showTaskHistory(task *model.Task) {
+	activePane := app.GetFocus()
+
+	activities, err := activityRepo.GetAllByTask(task.ID)
+	if err != nil {
+		statusBar.showForSeconds("[red]Could not load task history: "+err.Error(), 5)
+		return
+	}
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("[::b]Created:[::-]   %s\n", formatHistoryTime(&task.CreatedAt)))
+	content.WriteString(fmt.Sprintf("[::b]Updated:[::-]   %s\n", formatHistoryTime(&task.UpdatedAt)))
+	content.WriteString(fmt.Sprintf("[::b]Completed:[::-] %s\n\n", formatHistoryTime(task.CompletedAt)))
+
+	if len(activities) == 0 {
+		content.WriteString("[::d]No recorded changes")
+	}
+	for _, activity := range activities {
+		content.WriteString(fmt.Sprintf(
+			"[::d]%s[::-] [yellow]%-4s[-] %s\n",
+			activity.CreatedAt.Format(historyTimeLayout),
+			activity.Source,
+			describeActivity(activity),
+		))
+	}
+
+	historyView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(content.String())
+	historyView.ScrollToEnd()
+	historyView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			app.SetRoot(layout, true).EnableMouse(true)
+			app.SetFocus(activePane)
+			return nil
+		}
+		return event
+	})
+
+	showModal("History: "+task.Title+" (Esc to close)", historyView, 100, 0)
+	app.SetFocus(historyView)
+}
+
+// describeActivity makes a human readable line of a history entry
+func describeActivity(activity model.Activity) string {
+	switch activity.Action {
+	case model.ActivityCreated:
+		return fmt.Sprintf("Created \"%s\"", activity.NewValue)
+	case model.ActivityDeleted:
+		return "Moved to Trash"
+	case model.ActivityRestored:
+		return "Restored from Trash"
+	case model.ActivityPurged:
+		return "Deleted forever"
+	}
+
+	switch activity.Field {
+	case "Completed":
+		if activity.NewValue == "true" {
+			return "[green]Completed[-]"
+		}
+		return "Reopened"
+	case "Details":
+		return "Edited note"
+	case "Pinned":
+		if activity.NewValue == "true" {
+			return "Pinned"
+		}
+		return "Unpinned"
+	case "Status":
+		return fmt.Sprintf("Status: %s → %s", orNone(activity.OldValue), orNone(activity.NewValue))
+	case "DueDate":
+		return fmt.Sprintf("Due date: %s → %s", orNone(activity.OldValue), orNone(activity.NewValue))
+	case "DueTime":
+		return fmt.Sprintf("Due time: %s → %s", orNone(activity.OldValue), orNone(activity.NewValue))
+	case "StartDate":
+		return fmt.Sprintf("Start date: %s → %s", orNone(activity.OldValue), orNone(activity.NewValue))
+	case "Reminders":
+		return fmt.Sprintf("Reminders: %s → %s", orNone(activity.OldValue), orNone(activity.NewValue))
+	case "TimeZone":
+		return fmt.Sprintf("Time zone: %s → %s", orNone(activity.OldValue), orNone(activity.NewValue))
+	case "ProjectID":
+		return fmt.Sprintf("Moved from %s to %s", projectTitleOf(activity.OldValue), projectTitleOf(activity.NewValue))
+	case "ExternalKey":
+		if activity.NewValue == "" {
+			return "Unlinked from ticket " + activity.OldValue
+		}
+		return "Linked to ticket [lime]" + activity.NewValue + "[-]"
+	}
+
+	return fmt.Sprintf("%s: \"%s\" → \"%s\"", activity.Field, activity.OldValue, activity.NewValue)
+}
+
+func formatHistoryTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "[::d]Unknown[::-]"
+	}
+
+	return t.Format(historyTimeLayout)
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+
+	return value
+}
+
+// projectTitleOf finds the title of a project by its ID, as recorded in history
+func projectTitleOf(projectID string) string {
+	id, err := strconv.ParseInt(projectID, 10, 64)
+	if err != nil {
+		return projectID
+	}
+
+	if project, err := projectRepo.GetByID(id); err == nil {
+		return project.Title
+	}
+
+	return "project #" + projectID
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/remind"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// priorityWords are the words of priorities in inline task syntax, e.g. !high or !h
+var priorityWords = map[string]string{
+	"high": model.PriorityHigh, "h": model.PriorityHigh,
+	"medium": model.PriorityMedium, "med": model.PriorityMedium, "m": model.PriorityMedium,
+	"low": model.PriorityLow, "l": model.PriorityLow,
+}
+
+// priorityNames are the names of priorities to show, as they are saved by letter
+var priorityNames = map[string]string{
+	model.PriorityHigh: "high", model.PriorityMedium: "medium", model.PriorityLow: "low",
+}
+
+// taskInput is a new task written in one line with inline syntax,
+// e.g. "Write RFC due:fri #docs !high" is the task "Write RFC", due on Friday, tagged docs and of high priority
+type taskInput struct {
+	Title     string
+	Due       util.DateTime // Zero date if not set
+	StartDate time.Time     // Zero if not set
+	Reminders []model.Reminder
+	Tags      []string
+	Priority  string
+}
+
+// parseTaskInput takes due date (due:<date>), start date (start:<date>), reminders (remind:<when>), tags (#tag)
+// and priority (!high, !medium or !low) out of the title.
+// Words of a date are joined with - or _, e.g. due:next-fri-3pm, start:in_3_days or remind:1h,tue-9am.
+func parseTaskInput(text string, now time.Time) (taskInput, error) {
+	var input taskInput
+	var title []string
+
+	for _, word := range strings.Fields(text) {
+		lower := strings.ToLower(word)
+		switch {
+		case strings.HasPrefix(lower, "due:") && len(word) > len("due:"):
+			due, err := parseDueWord(word[len("due:"):], now)
+			if err != nil {
+				return input, err
+			}
+			input.Due = due
+		case strings.HasPrefix(lower, "start:") && len(word) > len("start:"):
+			start, err := parseDueWord(word[len("start:"):], now)
+			if err != nil {
+				return input, err
+			}
+			input.StartDate = start.Date
+		case strings.HasPrefix(lower, "remind:") && len(word) > len("remind:"):
+			for _, when := range strings.Split(word[len("remind:"):], ",") {
+				reminder, err := parseReminderWord(when, now)
+				if err != nil {
+					return input, err
+				}
+				if !hasReminder(input.Reminders, reminder) {
+					input.Reminders = append(input.Reminders, reminder)
+				}
+			}
+		case strings.HasPrefix(word, "#") && len(word) > 1 && unicode.IsLetter([]rune(word)[1]):
+			if tag := word[1:]; !util.InArray(tag, input.Tags) {
+				input.Tags = append(input.Tags, tag)
+			}
+		case strings.HasPrefix(word, "!") && priorityWords[lower[1:]] != "":
+			input.Priority = priorityWords[lower[1:]]
+		default:
+			title = append(title, word)
+		}
+	}
+
+	input.Title = strings.Join(title, " ")
+	return input, nil
+}
+
+// parseDueWord parses the date (and time) of due:<date>, as is (e.g. 2024-12-03)
+// or with - and _ as spaces (e.g. next-fri-3pm). Time zones keep their _, e.g. fri-9am-America/New_York.
+func parseDueWord(word string, now time.Time) (util.DateTime, error) {
+	if due, err := util.ParseDateTime(word, now); err == nil {
+		return due, nil
+	}
+
+	zone := ""
+	if slash := strings.Index(word, "/"); slash > 0 {
+		if i := strings.LastIndexAny(word[:slash], "-_"); i > 0 {
+			word, zone = word[:i], word[i+1:]
+		}
+	}
+	return util.ParseDateTime(strings.NewReplacer("-", " ", "_", " ").Replace(word)+" "+zone, now)
+}
+
+// parseReminderWord parses a reminder of remind:<when>, with words joined by - or _ as in parseDueWord
+func parseReminderWord(word string, now time.Time) (model.Reminder, error) {
+	dayStart := remind.ConfigFromEnv().DayStart
+	if reminder, err := parseReminder(strings.NewReplacer("-", " ", "_", " ").Replace(word), now, dayStart); err == nil {
+		return reminder, nil
+	}
+
+	at, err := parseDueWord(word, now)
+	if err != nil || at.Date.IsZero() {
+		return model.Reminder{}, fmt.Errorf("invalid reminder %q, use e.g. remind:15m, remind:1d or remind:fri-9am", word)
+	}
+	return parseReminder(strings.TrimSpace(at.Date.Format(dateLayoutISO)+" "+at.Clock+" "+at.TimeZone), now, dayStart)
+}
+
+// preview describes how the task will be saved, e.g. to show it while typing
+func (input taskInput) preview() string {
+	parts := []string{fmt.Sprintf("[white]%s[-]", input.Title)}
+	if task := input.newTask(model.Project{}); task.DueDate != 0 {
+		parts = append(parts, "due [lime]"+formatDue(task)+"[-]")
+	}
+	if !input.StartDate.IsZero() {
+		parts = append(parts, "starts [lime]"+input.StartDate.Format(dateLayoutHuman)+"[-]")
+	}
+	if len(input.Reminders) > 0 {
+		task := input.newTask(model.Project{})
+		parts = append(parts, "remind [lime]"+formatReminders(task)+"[-]")
+	}
+	for _, tag := range input.Tags {
+		parts = append(parts, "[aqua]#"+tag+"[-]")
+	}
+	if input.Priority != "" {
+		parts = append(parts, "priority [orange]"+priorityNames[input.Priority]+"[-]")
+	}
+
+	return strings.Join(parts, "  ·  ")
+}
+
+// newTask makes the task of input, in a project
+func (input taskInput) newTask(project model.Project) model.Task {
+	task := model.Task{
+		ProjectID: project.ID,
+		Title:     input.Title,
+		Tags:      input.Tags,
+		Priority:  input.Priority,
+		Reminders: input.Reminders,
+	}
+	setTaskDue(&task, input.Due)
+	task.SetStartDay(input.StartDate)
+
+	return task
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/repository"
+	"github.com/ajaxray/geek-life/ticketmanager"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// showProjectPicker lets the user pick a project by fuzzy matching its title.
+// The project of excludeID (e.g. the current project of a task) is not offered.
+func showProjectPicker(title string, excludeID int64, onSelect func(project model.Project)) {
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		statusBar.showForSeconds("[red]Could not load projects: "+err.Error(), 5)
+		return
+	}
+
+	activePane := app.GetFocus()
+	closePicker := func() {
+		app.SetRoot(layout, true).EnableMouse(true)
+		app.SetFocus(activePane)
+	}
+
+	queryInput := makeLightTextInput("Type to filter projects")
+	resultsList := tview.NewList().ShowSecondaryText(false)
+	resultsList.SetSelectedBackgroundColor(tcell.ColorDarkBlue)
+
+	var matches []model.Project
+	filter := func(query string) {
+		type match struct {
+			project model.Project
+			score   int
+		}
+		var found []match
+		for _, project := range projects {
+			if project.ID == excludeID {
+				continue
+			}
+			if score, ok := util.FuzzyScore(query, project.Title); ok {
+				found = append(found, match{project, score})
+			}
+		}
+		sort.SliceStable(found, func(i, j int) bool { return found[i].score > found[j].score })
+
+		resultsList.Clear()
+		matches = matches[:0]
+		for _, m := range found {
+			matches = append(matches, m.project)
+			resultsList.AddItem(m.project.Title, "", 0, nil)
+		}
+		if len(matches) == 0 {
+			resultsList.AddItem("[gray]No matching project", "", 0, nil)
+		}
+	}
+
+	choose := func(index int) {
+		if index < 0 || index >= len(matches) {
+			return
+		}
+		closePicker()
+		onSelect(matches[index])
+	}
+
+	queryInput.SetChangedFunc(filter)
+	queryInput.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closePicker()
+			return nil
+		case tcell.KeyEnter:
+			choose(resultsList.GetCurrentItem())
+			return nil
+		case tcell.KeyDown, tcell.KeyTab:
+			resultsList.SetCurrentItem(resultsList.GetCurrentItem() + 1)
+			return nil
+		case tcell.KeyUp, tcell.KeyBacktab:
+			resultsList.SetCurrentItem(resultsList.GetCurrentItem() - 1)
+			return nil
+		}
+		return event
+	})
+	resultsList.SetSelectedFunc(func(index int, _, _ string, _ rune) { choose(index) })
+	filter("")
+
+	picker := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(queryInput, 1, 0, true).
+		AddItem(blankCell, 1, 0, false).
+		AddItem(resultsList, 0, 1, false)
+	showModal(title+" (Enter = select, Esc = cancel)", picker, 60, 16)
+	app.SetFocus(queryInput)
+}
+
+// promptMoveTask asks for the project to move a task to
+func promptMoveTask(task *model.Task) {
+	if task == nil || task.IsDeleted() {
+		return
+	}
+
+	showProjectPicker("Move task to project", task.ProjectID, func(project model.Project) {
+		moveTask(task, project)
+	})
+}
+
+// moveTask moves a task to another project, reporting the result in status bar
+func moveTask(task *model.Task, project model.Project) {
+	ticketErr, err := relocateTask(task, project)
+	if err != nil {
+		statusBar.showForSeconds("[red]Could not move task: "+err.Error(), 5)
+		return
+	}
+	taskPane.refreshMovedTask(*task)
+
+	switch {
+	case ticketErr != nil:
+		util.LogError("Failed to move ticket %s to epic %s: %v", task.ExternalKey, project.ExternalKey, ticketErr)
+		statusBar.showForSeconds("[yellow]Moved task, but "+ticketErr.Error(), 8)
+	case task.IsLinked():
+		statusBar.showForSeconds(
+			fmt.Sprintf("[lime]Moved task to %s, and ticket %s under epic %s", project.Title, task.ExternalKey, project.ExternalKey),
+			5,
+		)
+	default:
+		statusBar.showForSeconds("[lime]Moved task to "+project.Title, 3)
+	}
+}
+
+// relocateTask moves a task to the bottom of another project.
+// A task linked to a ticket is also moved under the epic of the project, if linked with the same provider,
+// ticketErr tells why the ticket is not moved along.
+func relocateTask(task *model.Task, project model.Project) (ticketErr error, err error) {
+	moved := *task
+	moved.ProjectID = project.ID
+	moved.Order = 0
+	if tasks, err := taskRepo.GetAllByProject(project); err == nil {
+		moved.Order = repository.NextOrder(tasks)
+	}
+	if err := taskRepo.Update(&moved); err != nil {
+		return nil, err
+	}
+	*task = moved
+
+	if !moved.IsLinked() {
+		return nil, nil
+	}
+
+	provider := ticketmanager.ProviderType(moved.Provider)
+	if !project.IsLinked() || project.Provider != moved.Provider {
+		return fmt.Errorf("%s has no %s epic to move ticket %s under",
+			project.Title, provider.DisplayName(), moved.ExternalKey), nil
+	}
+
+	tm, _, err := projectTicketManager(project)
+	if err == nil {
+		err = tm.MoveTask(moved.ExternalKey, project.ExternalKey)
+	}
+	if err != nil {
+		return fmt.Errorf("could not move %s ticket: %w", provider.DisplayName(), err), nil
+	}
+
+	return nil, nil
+}
+
+// duplicateTask creates a copy of a task, at the bottom of its project.
+// The copy is pending and not linked to the ticket of the original.
+func duplicateTask(task *model.Task) {
+	if task == nil || task.IsDeleted() {
+		return
+	}
+
+	duplicate := model.Task{
+		ProjectID: task.ProjectID,
+		Title:     task.Title + " (copy)",
+		Details:   task.Details,
+		DueDate:   task.DueDate,
+		DueTime:   task.DueTime,
+		StartDate: task.StartDate,
+		TimeZone:  task.TimeZone,
+		Reminders: task.Reminders,
+		Priority:  task.Priority,
+		Tags:      append([]string(nil), task.Tags...),
+	}
+	if err := taskRepo.CreateTask(&duplicate); err != nil {
+		statusBar.showForSeconds("[red]Could not duplicate task: "+err.Error(), 5)
+		return
+	}
+
+	taskPane.tasks = append(taskPane.tasks, duplicate)
+	taskPane.addTaskToList(len(taskPane.tasks) - 1)
+	statusBar.showForSeconds("[lime]Duplicated task: "+duplicate.Title, 3)
+}
+
+// refreshMovedTask updates the listing of a task moved to another project.
+// It's taken out of a project list, while dynamic lists only show the new project.
+func (pane *TaskPane) refreshMovedTask(task model.Task) {
+	index := -1
+	for i := range pane.tasks {
+		if pane.tasks[i].ID == task.ID {
+			index = i
+		}
+	}
+	if index == -1 {
+		return
+	}
+
+	if projectPane.GetActiveProject() == nil {
+		pane.tasks[index] = task
+		pane.list.SetItemText(index, pane.listingTitle(index), "")
+		return
+	}
+
+	pane.removeTasks(map[int64]bool{task.ID: true})
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/ajaxray/geek-life/repository"
+)
+
+// canReorder checks if tasks of the current list can be moved manually.
+// Only project lists have an order of their own, dynamic lists mix tasks of many projects.
+func (pane *TaskPane) canReorder() bool {
+	if pane.showingTrash || projectPane.GetActiveProject() == nil {
+		statusBar.showForSeconds("[yellow]Tasks can be reordered in a project only", 3)
+		return false
+	}
+
+	return true
+}
+
+// moveTaskTo moves the task at index from to index to, within its group (pinned or not), and saves the new order
+func (pane *TaskPane) moveTaskTo(from, to int) {
+	if from < 0 || from >= len(pane.tasks) || to < 0 || to >= len(pane.tasks) || from == to {
+		return
+	}
+	if !pane.canReorder() {
+		return
+	}
+	if pane.tasks[from].Pinned != pane.tasks[to].Pinned {
+		statusBar.showForSeconds("[yellow]Pinned tasks stay on top, pin or unpin the task (press *) to move it across", 3)
+		return
+	}
+
+	task := pane.tasks[from]
+	if from < to {
+		copy(pane.tasks[from:to], pane.tasks[from+1:to+1])
+	} else {
+		copy(pane.tasks[to+1:from+1], pane.tasks[to:from])
+	}
+	pane.tasks[to] = task
+
+	for i := range pane.tasks {
+		if pane.tasks[i].Order != int64(i+1) {
+			if err := pane.taskRepo.UpdateField(&pane.tasks[i], "Order", int64(i+1)); err != nil {
+				statusBar.showForSeconds("[red]Could not save task order: "+err.Error(), 5)
+				break
+			}
+		}
+	}
+
+	pane.redrawList(task.ID)
+}
+
+// togglePinned pins the selected task on top of the list, or unpins it
+func (pane *TaskPane) togglePinned() {
+	index := pane.list.GetCurrentItem()
+	if pane.showingTrash || index < 0 || index >= len(pane.tasks) {
+		return
+	}
+
+	task := &pane.tasks[index]
+	if err := pane.taskRepo.UpdateField(task, "Pinned", !task.Pinned); err != nil {
+		statusBar.showForSeconds("[red]Could not pin task: "+err.Error(), 5)
+		return
+	}
+
+	if task.Pinned {
+		statusBar.showForSeconds("[lime]Pinned task: "+task.Title, 3)
+	} else {
+		statusBar.showForSeconds("[lime]Unpinned task: "+task.Title, 3)
+	}
+
+	id := task.ID
+	repository.SortTasks(pane.tasks)
+	pane.redrawList(id)
+}
+
+// redrawList lists pane.tasks again, keeping the task of selectedID selected (and active, if it was)
+func (pane *TaskPane) redrawList(selectedID int64) {
+	var activeID int64
+	if pane.activeTask != nil {
+		activeID = pane.activeTask.ID
+	}
+
+	pane.list.Clear()
+	for i := range pane.tasks {
+		pane.addTaskToList(i)
+
+		if pane.tasks[i].ID == selectedID {
+			pane.list.SetCurrentItem(i)
+		}
+		if activeID != 0 && pane.tasks[i].ID == activeID {
+			pane.activeTask = &pane.tasks[i]
+			taskDetailPane.SetTask(pane.activeTask)
+		}
+	}
+}
+
+// handleDrag moves a task dragged by mouse to where it's dropped
+func (pane *TaskPane) handleDrag(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+	x, y := event.Position()
+
+	switch action {
+	case tview.MouseScrollUp, tview.MouseScrollDown:
+		pane.scroll.scrolled(action, x, y)
+	case tview.MouseLeftDown:
+		pane.dragFrom = pane.scroll.indexAt(y)
+	case tview.MouseLeftUp:
+		from, to := pane.dragFrom, pane.scroll.indexAt(y)
+		pane.dragFrom = -1
+		if from != -1 && to != -1 && from != to {
+			pane.moveTaskTo(from, to)
+			// Consumed by capture, the event does not redraw the screen
+			app.ForceDraw()
+			return action, nil
+		}
+	}
+
+	return action, event
+}
+
+// listScroll follows the scroll offset of a list, which tview does not expose.
+// The list only moves its offset when drawn, to keep the current item in view,
+// and when scrolled by mouse wheel. Lists showing secondary text are not supported.
+type listScroll struct {
+	list   *tview.List
+	offset int
+}
+
+// drawn adjusts the offset the way the list did when it was last drawn
+func (s *listScroll) drawn() {
+	_, _, _, height := s.list.GetInnerRect()
+	current := s.list.GetCurrentItem()
+	if current < s.offset {
+		s.offset = current
+	} else if current-s.offset >= height {
+		s.offset = current + 1 - height
+	}
+}
+
+// scrolled follows the offset for mouse wheel actions at screen position x, y
+func (s *listScroll) scrolled(action tview.MouseAction, x, y int) {
+	if !s.list.InRect(x, y) {
+		return
+	}
+
+	s.drawn()
+	_, _, _, height := s.list.GetInnerRect()
+	switch action {
+	case tview.MouseScrollUp:
+		if s.offset > 0 {
+			s.offset--
+		}
+	case tview.MouseScrollDown:
+		if s.list.GetItemCount()-s.offset > height {
+			s.offset++
+		}
+	}
+}
+
+// indexAt is the index of the list item at screen row y, -1 if none
+func (s *listScroll) indexAt(y int) int {
+	s.drawn()
+	_, top, _, height := s.list.GetInnerRect()
+	if y < top || y >= top+height {
+		return -1
+	}
+
+	index := s.offset + y - top
+	if index >= s.list.GetItemCount() {
+		return -1
+	}
+	return index
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/rivo/tview"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/remind"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// startReminders notifies of reminders while the app is open. Returns a function to stop it.
+// The bolt database is locked while the app is open, so `geek-life remind --daemon` leaves it to the app.
+// Markdown files are read by the daemon anyway.
+func startReminders() func() {
+	if db == nil {
+		return func() {}
+	}
+
+	config := remind.ConfigFromEnv()
+	var notifier remind.Notifier // nil for bell, see appNotifier
+	if remind.ResolveKind(config.Notifier, config.Command) != remind.NotifierBell {
+		var err error
+		if notifier, err = remind.NewNotifier(config.Notifier, config.Command, nil); err != nil {
+			util.LogError("Reminders are off: %v", err)
+			return func() {}
+		}
+	}
+
+	load := func() ([]model.Task, error) {
+		tasks, err := taskRepo.GetAll()
+		if err == storm.ErrNotFound {
+			return nil, nil
+		}
+		return tasks, err
+	}
+	return remind.Schedule(config, load, appNotifier{notifier}, func(err error) {
+		util.LogError("Checking reminders failed: %v", err)
+	})
+}
+
+// appNotifier shows reminders in status bar, besides notifying by notifier.
+// Without notifier it just rings the bell, as writing reminders would break the screen.
+type appNotifier struct {
+	notifier remind.Notifier
+}
+
+func (n appNotifier) Notify(note remind.Notification) error {
+	app.QueueUpdateDraw(func() {
+		statusBar.showForSeconds(fmt.Sprintf("[yellow]⏰ %s[-] - %s", tview.Escape(note.Title), note.Message), 15)
+	})
+
+	if n.notifier == nil {
+		_, err := fmt.Fprint(os.Stdout, "\a")
+		return err
+	}
+	return n.notifier.Notify(note)
+}
+
+// parseReminders parses reminders separated by comma: durations before due time (e.g. 15m, 1h30m, 1d, 2 hours),
+// "at due" or dates (e.g. fri 9am, tomorrow, dec 3 14:00) to remind at. Dates without time are at dayStart.
+// Empty or "none" is no reminder.
+func parseReminders(text string, now time.Time, dayStart time.Duration) ([]model.Reminder, error) {
+	if strings.EqualFold(strings.TrimSpace(text), "none") {
+		return nil, nil
+	}
+
+	var reminders []model.Reminder
+	for _, part := range strings.Split(text, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		reminder, err := parseReminder(part, now, dayStart)
+		if err != nil {
+			return nil, err
+		}
+		if !hasReminder(reminders, reminder) {
+			reminders = append(reminders, reminder)
+		}
+	}
+
+	return reminders, nil
+}
+
+func parseReminder(text string, now time.Time, dayStart time.Duration) (model.Reminder, error) {
+	text = strings.TrimSpace(text)
+	if lower := strings.ToLower(text); lower == "at due" || lower == "due" {
+		return model.Reminder{}, nil
+	}
+	if before, err := model.ParseBefore(text); err == nil {
+		return model.Reminder{Before: before}, nil
+	}
+
+	at, err := util.ParseDateTime(text, now)
+	if err != nil || at.Date.IsZero() {
+		return model.Reminder{}, fmt.Errorf("invalid reminder %q, use e.g. 15m, 1d, at due or fri 9am", text)
+	}
+
+	loc := time.Local
+	if zone, err := time.LoadLocation(at.TimeZone); err == nil && at.TimeZone != "" {
+		loc = zone
+	}
+	seconds := int(dayStart / time.Second)
+	if clock, err := time.Parse("15:04", at.Clock); err == nil {
+		seconds = clock.Hour()*3600 + clock.Minute()*60
+	}
+	year, month, day := at.Date.Date()
+	return model.Reminder{At: time.Date(year, month, day, 0, 0, seconds, 0, loc).Unix()}, nil
+}
+
+func hasReminder(reminders []model.Reminder, reminder model.Reminder) bool {
+	for _, r := range reminders {
+		if r == reminder {
+			return true
+		}
+	}
+	return false
+}
+
+// formatReminders describes reminders of a task, e.g. "15m before, 23 Oct, Friday 09:00". Past ones are dim.
+func formatReminders(task model.Task) string {
+	now, dayStart := time.Now(), remind.ConfigFromEnv().DayStart
+	texts := make([]string, 0, len(task.Reminders))
+	for _, reminder := range task.Reminders {
+		text := formatReminder(reminder)
+		if at := reminder.Time(task, dayStart, time.Local); !at.IsZero() && at.Before(now) {
+			text = "[::d]" + text + "[::-]"
+		}
+		texts = append(texts, text)
+	}
+
+	return strings.Join(texts, ", ")
+}
+
+func formatReminder(reminder model.Reminder) string {
+	switch {
+	case reminder.At != 0:
+		return time.Unix(reminder.At, 0).Format(dateLayoutHuman + " 15:04")
+	case reminder.Before == 0:
+		return "at due time"
+	}
+
+	return model.FormatDuration(reminder.Before) + " before"
+}
+
+// reminderInput writes reminders of a task the way they are typed, e.g. "15m, 2024-12-03 09:00"
+func reminderInput(task model.Task) string {
+	texts := make([]string, 0, len(task.Reminders))
+	for _, reminder := range task.Reminders {
+		switch {
+		case reminder.At != 0:
+			texts = append(texts, time.Unix(reminder.At, 0).Format(dateLayoutISO+" 15:04"))
+		case reminder.Before == 0:
+			texts = append(texts, "at due")
+		default:
+			texts = append(texts, model.FormatDuration(reminder.Before))
+		}
+	}
+
+	return strings.Join(texts, ", ")
+}
+
+// hasUpcomingReminder checks if an open task has a reminder yet to come
+func hasUpcomingReminder(task model.Task, now time.Time) bool {
+	if task.Completed {
+		return false
+	}
+
+	dayStart := remind.ConfigFromEnv().DayStart
+	for _, reminder := range task.Reminders {
+		if reminder.Time(task, dayStart, time.Local).After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateTaskReminders sets reminders of a task (nil to remove) and saves it
+func updateTaskReminders(task *model.Task, reminders []model.Reminder) error {
+	updated := *task
+	updated.Reminders = reminders
+	if err := taskRepo.Update(&updated); err != nil {
+		return err
+	}
+
+	*task = updated
+	return nil
+}
+
+// promptTaskReminders asks for reminders of a task, notified by `geek-life remind --daemon` or the open app
+func promptTaskReminders(task *model.Task, onChange func()) {
+	if task == nil || task.IsDeleted() {
+		return
+	}
+
+	showInputPrompt("Reminders (comma separated)", "Remind (e.g. 15m, 1d, at due, fri 9am, none): ", reminderInput(*task), func(text string) {
+		reminders, err := parseReminders(text, time.Now(), remind.ConfigFromEnv().DayStart)
+		if err != nil {
+			statusBar.showForSeconds("[red]"+err.Error(), 8)
+			return
+		}
+		if err := updateTaskReminders(task, reminders); err != nil {
+			statusBar.showForSeconds("[red]Could not set reminders: "+err.Error(), 5)
+			return
+		}
+
+		onChange()
+		switch {
+		case len(reminders) == 0:
+			statusBar.showForSeconds("[lime]Reminders removed", 3)
+		case task.DueDate == 0 && needsDueDate(reminders):
+			statusBar.showForSeconds("[yellow]Reminders before due time need a due date to be notified", 5)
+		default:
+			statusBar.showForSeconds("[lime]Remind "+formatReminders(*task), 3)
+		}
+	})
+}
+
+// needsDueDate checks if any of reminders is relative to due time
+func needsDueDate(reminders []model.Reminder) bool {
+	for _, reminder := range reminders {
+		if reminder.At == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/ticketmanager"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// statusColors are the colors of task titles by status, see getTaskTitleColor
+var statusColors = map[string]string{
+	model.StatusInProgress: "deepskyblue",
+	model.StatusBlocked:    "fuchsia",
+	model.StatusWaiting:    "violet",
+	model.StatusDone:       "green",
+	model.StatusCancelled:  "gray",
+}
+
+// statusColor is the color of a task status, for text of statuses without a color of their own (i.e. To Do)
+func statusColor(status string) string {
+	if color, ok := statusColors[status]; ok {
+		return color
+	}
+	return "smokewhite"
+}
+
+// makeStatusText describes the status of a task, along with the status name in its ticket provider
+func makeStatusText(task model.Task) string {
+	status := task.CurrentStatus()
+	text := fmt.Sprintf("Status: [%s::b]%s[-::-]", statusColor(status), status)
+	if task.IsLinked() && task.RemoteStatus != "" {
+		provider := ticketmanager.ProviderType(task.Provider)
+		text += fmt.Sprintf(" [gray](%s: %s)[-]", provider.DisplayName(), task.RemoteStatus)
+	}
+
+	return text
+}
+
+// promptTaskStatus lets the user pick a new status for a task
+func promptTaskStatus(task *model.Task) {
+	if task == nil || task.IsDeleted() {
+		return
+	}
+
+	showStatusPicker(task.CurrentStatus(), func(status string) {
+		changeTaskStatus(task, status)
+	})
+}
+
+// showStatusPicker lets the user pick a status by number or arrow keys, starting from current status
+func showStatusPicker(current string, onSelect func(status string)) {
+	activePane := app.GetFocus()
+	closeMenu := func() {
+		app.SetRoot(layout, true).EnableMouse(true)
+		app.SetFocus(activePane)
+	}
+
+	menu := tview.NewList().ShowSecondaryText(false)
+	menu.SetSelectedBackgroundColor(tcell.ColorDarkBlue)
+	for i, status := range model.Statuses {
+		menu.AddItem(fmt.Sprintf("[%s]%s", statusColor(status), status), "", rune('1'+i), func(status string) func() {
+			return func() {
+				closeMenu()
+				onSelect(status)
+			}
+		}(status))
+		if status == current {
+			menu.SetCurrentItem(i)
+		}
+	}
+
+	menu.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			closeMenu()
+			return nil
+		}
+		return event
+	})
+
+	showModal("Change status (Esc to close)", menu, 40, len(model.Statuses)+2)
+	app.SetFocus(menu)
+}
+
+// changeTaskStatus sets status of a task from task list or detail, reporting the result in status bar
+func changeTaskStatus(task *model.Task, status string) {
+	change, err := setTaskStatus(task, status)
+	if err != nil {
+		statusBar.showForSeconds("[red]Could not change status: "+err.Error(), 5)
+		return
+	}
+	taskPane.refreshListing()
+	if taskPane.activeTask != nil && taskPane.activeTask.ID == task.ID {
+		taskDetailPane.SetTask(taskPane.activeTask)
+	}
+
+	change.report("Status changed to " + status)
+}
+
+// statusChange is the result of setTaskStatus for the linked ticket of the task
+type statusChange struct {
+	ticketErr error // Why the ticket is not changed along, nil if it is or there's no ticket
+}
+
+// report shows the change in status bar, warning if the ticket is not changed along, e.g. "Moved to Done"
+func (change statusChange) report(message string) {
+	if change.ticketErr != nil {
+		statusBar.showForSeconds(fmt.Sprintf("[yellow]%s, but %s", message, change.ticketErr.Error()), 8)
+	} else {
+		statusBar.showForSeconds("[lime]"+message, 3)
+	}
+}
+
+// setTaskStatus changes status of a task (completing it if Done or Cancelled), and of its linked ticket.
+// Failing to change the ticket does not fail it, the result tells why instead.
+func setTaskStatus(task *model.Task, status string) (statusChange, error) {
+	updated := *task
+	updated.Status = status
+	updated.Completed = model.IsClosedStatus(status)
+	if err := taskRepo.Update(&updated); err != nil {
+		return statusChange{}, err
+	}
+	*task = updated
+
+	if !task.IsLinked() {
+		return statusChange{}, nil
+	}
+
+	project, _ := projectRepo.GetByID(task.ProjectID)
+	tm, provider, err := projectTicketManager(project)
+	remoteStatus := ""
+	if err == nil {
+		remoteStatus, err = tm.TransitionTask(task.ExternalKey, status)
+	}
+	if err != nil {
+		util.LogError("Failed to change status of ticket %s: %v", task.ExternalKey, err)
+		return statusChange{fmt.Errorf("could not change status of %s ticket: %w", provider.DisplayName(), err)}, nil
+	}
+
+	if remoteStatus != "" && remoteStatus != task.RemoteStatus {
+		if err := taskRepo.WithSource(model.ActivitySourceSync).UpdateField(task, "RemoteStatus", remoteStatus); err != nil {
+			util.LogWarning("Could not save status of ticket %s: %v", task.ExternalKey, err)
+		}
+	}
+
+	return statusChange{}, nil
+}
@@ -39,7 +39,9 @@ func NewTaskDetailHeader(taskRepo repository.TaskRepository) *TaskDetailHeader {
 		AddItem(blankCell, 0, 1, false).
 		AddItem(makeButton("[::ub]r[::-]ename", func() { header.ShowRename() }), 8, 0, false).
 		AddItem(blankCell, 1, 0, false).
-		AddItem(makeButton("e[::ub]x[::-]port", func() { taskDetailPane.Export() }), 8, 0, false)
+		AddItem(makeButton("e[::ub]x[::-]port", func() { taskDetailPane.Export() }), 8, 0, false).
+		AddItem(blankCell, 1, 0, false).
+		AddItem(makeButton("h[::ub]i[::-]story", func() { showTaskHistory(header.task) }), 9, 0, false)
 
 	header.
 		AddItem(header.pages, 1, 1, true).
@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"sort"
+	"strings"
 	"time"
 	"unicode"
 
@@ -10,6 +10,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/ajaxray/geek-life/export"
 	"github.com/ajaxray/geek-life/model"
 	"github.com/ajaxray/geek-life/repository"
 	"github.com/ajaxray/geek-life/ticketmanager"
@@ -37,13 +38,20 @@ type TaskPane struct {
 	tasks      []model.Task
 	activeTask *model.Task
 
-	newTask       *tview.InputField
-	projectRepo   repository.ProjectRepository
-	taskRepo      repository.TaskRepository
-	hint          *tview.TextView
-	ticketManager ticketmanager.TicketManager
-	providerType  ticketmanager.ProviderType
-	lastGKeyTime  int64 // Timestamp for tracking double 'g' press
+	newTask      *tview.InputField
+	projectRepo  repository.ProjectRepository
+	taskRepo     repository.TaskRepository
+	hint         *tview.TextView
+	lastGKeyTime int64       // Timestamp for tracking double 'g' press
+	dragFrom     int         // Index of the task being dragged by mouse, -1 if none
+	scroll       *listScroll // Scroll offset of list, for finding the task under mouse
+
+	marked map[int64]bool // IDs of tasks selected for bulk actions
+
+	listTitle     string          // Name of the project or dynamic list being displayed
+	showUnstarted bool            // Listing tasks of projects that are hidden until their start date
+	showingTrash  bool            // Listing deleted items instead of tasks
+	trashProjects []model.Project // Deleted projects, listed before deleted tasks in Trash
 }
 
 // NewTaskPane initializes and configures a TaskPane
@@ -54,37 +62,38 @@ func NewTaskPane(
 	pane := TaskPane{
 		Flex:        tview.NewFlex().SetDirection(tview.FlexRow),
 		list:        tview.NewList().ShowSecondaryText(false),
-		newTask:     makeLightTextInput("+[New Task]"),
+		newTask:     makeLightTextInput("+[New Task] (e.g. Write RFC due:fri #docs !high)"),
 		projectRepo: projectRepo,
 		taskRepo:    taskRepo,
+		dragFrom:    -1,
 		hint: tview.NewTextView().
 			SetTextColor(tcell.ColorYellow).
 			SetTextAlign(tview.AlignCenter),
-		providerType: ticketmanager.GetProviderType(),
-	}
-
-	if ticketmanager.IsAnyProviderConfigured() {
-		if tm, err := ticketmanager.NewTicketManager(); err == nil {
-			pane.ticketManager = tm
-		}
 	}
 
+	pane.scroll = &listScroll{list: pane.list}
 	pane.list.SetSelectedBackgroundColor(tcell.ColorDarkBlue)
 	pane.list.SetDoneFunc(func() {
 		app.SetFocus(projectPane)
 	})
+	pane.list.SetMouseCapture(pane.handleDrag)
 
+	pane.newTask.SetChangedFunc(pane.previewNewTask)
 	pane.newTask.SetDoneFunc(func(key tcell.Key) {
 		switch key {
 		case tcell.KeyEnter:
-			name := pane.newTask.GetText()
-			if len(name) < 3 {
+			input, err := parseTaskInput(pane.newTask.GetText(), time.Now())
+			if err != nil {
+				statusBar.showForSeconds("[red::]"+err.Error(), 8)
+				return
+			}
+			if len(input.Title) < 3 {
 				statusBar.showForSeconds("[red::]Task title should be at least 3 character", 5)
 				return
 			}
 
-			task, err := taskRepo.Create(*projectPane.GetActiveProject(), name, "", "", 0)
-			if err != nil {
+			task := input.newTask(*projectPane.GetActiveProject())
+			if err := taskRepo.CreateTask(&task); err != nil {
 				statusBar.showForSeconds("[red::]Could not create Task:"+err.Error(), 5)
 				return
 			}
@@ -108,11 +117,25 @@ func NewTaskPane(
 	return &pane
 }
 
+// previewNewTask shows how the new task being typed will be saved, if it has due date, tags or priority
+func (pane *TaskPane) previewNewTask(text string) {
+	input, err := parseTaskInput(text, time.Now())
+	switch {
+	case err != nil:
+		statusBar.showForSeconds("[red::]"+err.Error(), 10)
+	case !input.Due.Date.IsZero() || !input.StartDate.IsZero() || len(input.Tags) > 0 || input.Priority != "":
+		statusBar.showForSeconds("[yellow::]New task:[-::]  "+input.preview(), 10)
+	}
+}
+
 // ClearList removes all items from TaskPane
 func (pane *TaskPane) ClearList() {
 	pane.list.Clear()
 	pane.tasks = nil
 	pane.activeTask = nil
+	pane.showingTrash = false
+	pane.trashProjects = nil
+	pane.marked = nil
 
 	pane.RemoveItem(pane.newTask)
 }
@@ -128,12 +151,27 @@ func (pane *TaskPane) SetList(tasks []model.Task) {
 }
 
 func (pane *TaskPane) addTaskToList(i int) *tview.List {
-	return pane.list.AddItem(makeTaskListingTitle(pane.tasks[i]), "", 0, func(taskidx int) func() {
+	return pane.list.AddItem(pane.listingTitle(i), "", 0, func(taskidx int) func() {
 		return func() { taskPane.ActivateTask(taskidx) }
 	}(i))
 }
 
+// listingTitle is the list item text of the task at index i, marked if selected for bulk actions
+func (pane *TaskPane) listingTitle(i int) string {
+	title := makeTaskListingTitle(pane.tasks[i])
+	if pane.marked[pane.tasks[i].ID] {
+		return "[orange]●[-] " + title
+	}
+	return title
+}
+
 func (pane *TaskPane) handleShortcuts(event *tcell.EventKey) *tcell.EventKey {
+	if pane.showingTrash {
+		if event = pane.handleTrashShortcuts(event); event == nil {
+			return nil
+		}
+	}
+
 	// Handle Shift+G (uppercase G) BEFORE the lowercase conversion
 	if event.Rune() == 'G' {
 		// Go to bottom
@@ -144,6 +182,65 @@ func (pane *TaskPane) handleShortcuts(event *tcell.EventKey) *tcell.EventKey {
 		return nil
 	}
 
+	// Shift+J / Shift+K move the selected task down / up
+	switch event.Rune() {
+	case 'J':
+		current := pane.list.GetCurrentItem()
+		pane.moveTaskTo(current, current+1)
+		return nil
+	case 'K':
+		current := pane.list.GetCurrentItem()
+		pane.moveTaskTo(current, current-1)
+		return nil
+	case '*':
+		pane.togglePinned()
+		return nil
+	}
+
+	if !pane.showingTrash {
+		switch {
+		case event.Key() == tcell.KeyEsc && len(pane.marked) > 0:
+			pane.clearMarks()
+			return nil
+		case event.Key() == tcell.KeyCtrlA:
+			pane.toggleMarkAll()
+			return nil
+		case event.Rune() == ' ' || unicode.ToLower(event.Rune()) == 'v':
+			pane.toggleMark()
+			return nil
+		case unicode.ToLower(event.Rune()) == 'a':
+			pane.showBulkActions()
+			return nil
+		case unicode.ToLower(event.Rune()) == 'f':
+			pane.toggleUnstarted()
+			return nil
+		case unicode.ToLower(event.Rune()) == 'b':
+			if project := projectPane.GetActiveProject(); project != nil {
+				showBoard(*project)
+			} else {
+				statusBar.showForSeconds("[yellow]Board is available for projects only", 3)
+			}
+			return nil
+		}
+	}
+
+	if index := pane.list.GetCurrentItem(); !pane.showingTrash && index >= 0 && index < len(pane.tasks) {
+		switch unicode.ToLower(event.Rune()) {
+		case 'm':
+			promptMoveTask(&pane.tasks[index])
+			return nil
+		case 'y':
+			duplicateTask(&pane.tasks[index])
+			return nil
+		case 's':
+			promptTaskStatus(&pane.tasks[index])
+			return nil
+		case 'w':
+			promptTaskStart(&pane.tasks[index], pane.refreshListing)
+			return nil
+		}
+	}
+
 	switch unicode.ToLower(event.Rune()) {
 	case 'j':
 		pane.list.SetCurrentItem(pane.list.GetCurrentItem() + 1)
@@ -157,6 +254,9 @@ func (pane *TaskPane) handleShortcuts(event *tcell.EventKey) *tcell.EventKey {
 	case 'n':
 		app.SetFocus(pane.newTask)
 		return nil
+	case 'x':
+		pane.ExportList()
+		return nil
 	case 'g':
 		now := time.Now().UnixMilli()
 		if now-pane.lastGKeyTime < 500 { // 500ms window for double press
@@ -179,27 +279,19 @@ func (pane *TaskPane) handleShortcuts(event *tcell.EventKey) *tcell.EventKey {
 		}
 
 		task := pane.tasks[selectedIndex]
-		if task.JiraID != "" && pane.ticketManager != nil {
-			var ticketURL string
-			var providerName string
-
-			switch pane.providerType {
-			case ticketmanager.ProviderJira:
-				jiraConfig := util.GetJiraConfig()
-				ticketURL = fmt.Sprintf("%s/browse/%s", jiraConfig.URL, task.JiraID)
-				providerName = "JIRA"
-			case ticketmanager.ProviderLinear:
-				ticketURL = fmt.Sprintf("https://linear.app/team/issue/%s", task.JiraID)
-				providerName = "Linear"
-			}
-
-			err := util.OpenInBrowser(ticketURL)
+		project, _ := pane.projectRepo.GetByID(task.ProjectID)
+		_, provider, tmErr := projectTicketManager(project)
+		if task.IsLinked() && tmErr == nil {
+			err := util.OpenInBrowser(ticketmanager.LinkURL(task.ExternalLink))
 			if err != nil {
 				statusBar.showForSeconds("[red]Failed to open browser: "+err.Error(), 5)
 			} else {
-				statusBar.showForSeconds(fmt.Sprintf("[lime]Opened %s task in browser", providerName), 3)
+				statusBar.showForSeconds(
+					fmt.Sprintf("[lime]Opened %s task in browser", provider.DisplayName()),
+					3,
+				)
 			}
-		} else if task.JiraID == "" {
+		} else if !task.IsLinked() {
 			statusBar.showForSeconds("[yellow]Task has no ticket associated", 3)
 		} else {
 			statusBar.showForSeconds("[yellow]Ticket manager not configured", 3)
@@ -207,13 +299,9 @@ func (pane *TaskPane) handleShortcuts(event *tcell.EventKey) *tcell.EventKey {
 		return nil
 	case tcell.KeyCtrlJ:
 		// Check if ticket manager is configured
-		if pane.ticketManager == nil {
-			providerName := string(pane.providerType)
+		if !tickets.IsConfigured() {
 			statusBar.showForSeconds(
-				fmt.Sprintf(
-					"[red]%s not configured. Set required environment variables.",
-					providerName,
-				),
+				"[red]No ticket provider configured. Set required environment variables.",
 				8,
 			)
 			return nil
@@ -226,59 +314,74 @@ func (pane *TaskPane) handleShortcuts(event *tcell.EventKey) *tcell.EventKey {
 		}
 
 		task := pane.tasks[selectedIndex]
-		if task.JiraID == "" {
-			project, err := pane.projectRepo.GetByID(task.ProjectID)
-			if err != nil {
-				statusBar.showForSeconds("[red]Failed to get project: "+err.Error(), 5)
-				return nil
-			}
+		if task.IsLinked() {
+			statusBar.showForSeconds("[yellow]Task already has ticket ID: "+task.ExternalKey, 3)
+			return nil
+		}
 
-			if project.Jira == "" {
-				providerName := string(pane.providerType)
-				statusBar.showForSeconds(
-					fmt.Sprintf(
-						"[red]Project has no %s epic. Create epic first (Ctrl+J in Projects pane).",
-						providerName,
-					),
-					5,
-				)
-				return nil
-			}
+		provider, err := pushTaskToTicket(&task)
+		if err != nil {
+			statusBar.showForSeconds("[red]"+err.Error(), 5)
+			return nil
+		}
+		pane.tasks[selectedIndex] = task
+		pane.list.SetItemText(selectedIndex, pane.listingTitle(selectedIndex), "")
 
-			issue, err := pane.ticketManager.DescribeEpic(project.Jira)
-			if err != nil {
-				statusBar.showForSeconds("[red]Failed to get epic details: "+err.Error(), 5)
-				return nil
-			}
+		statusBar.showForSeconds(fmt.Sprintf("[lime]%s task created: %s", provider.DisplayName(), task.ExternalKey), 5)
+		return nil
+	}
 
-			t, err := pane.ticketManager.CreateTask(
-				task.Title,
-				task.Details,
-				issue.Key,
-			)
-			if err != nil {
-				providerName := string(pane.providerType)
-				statusBar.showForSeconds(
-					fmt.Sprintf("[red]Failed to create %s task: %s", providerName, err.Error()),
-					5,
-				)
-				return nil
-			}
+	return event
+}
 
-			task.JiraID = t
-			_ = pane.taskRepo.Update(&task)
-			pane.LoadProjectTasks(project)
-			pane.list.SetCurrentItem(selectedIndex)
+// pushTaskToTicket creates a ticket of a task, under the epic of its project, and links the task with it
+func pushTaskToTicket(task *model.Task) (ticketmanager.ProviderType, error) {
+	project, err := projectRepo.GetByID(task.ProjectID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get project: %w", err)
+	}
 
-			providerName := string(pane.providerType)
-			statusBar.showForSeconds(fmt.Sprintf("[lime]%s task created: %s", providerName, t), 5)
-		} else {
-			statusBar.showForSeconds("[yellow]Task already has ticket ID: "+task.JiraID, 3)
-		}
-		return nil
+	tm, provider, err := projectTicketManager(project)
+	if !project.IsLinked() {
+		return provider, fmt.Errorf(
+			"project has no %s epic. Create epic first (Ctrl+J in Projects pane)", provider.DisplayName(),
+		)
+	}
+	if err != nil {
+		return provider, err
 	}
 
-	return event
+	issue, err := tm.DescribeEpic(project.ExternalKey)
+	if err != nil {
+		return provider, fmt.Errorf("failed to get epic details: %w", err)
+	}
+
+	key, err := tm.CreateTask(task.Title, task.Details, issue.Key)
+	if err != nil {
+		return provider, fmt.Errorf("failed to create %s task: %w", provider.DisplayName(), err)
+	}
+
+	task.ExternalLink = model.ExternalLink{
+		Provider:    string(provider),
+		ExternalKey: key,
+		ExternalURL: provider.TicketURL(key),
+	}
+	return provider, taskRepo.Update(task)
+}
+
+// toggleUnstarted shows or hides tasks of the project that are not started yet
+func (pane *TaskPane) toggleUnstarted() {
+	project := projectPane.GetActiveProject()
+	if project == nil {
+		statusBar.showForSeconds("[yellow]Tasks not started yet are hidden in projects only", 3)
+		return
+	}
+
+	pane.showUnstarted = !pane.showUnstarted
+	pane.LoadProjectTasks(*project)
+	if pane.showUnstarted {
+		statusBar.showForSeconds("[yellow]Showing tasks not started yet, press f to hide", 3)
+	}
 }
 
 // LoadProjectTasks loads tasks of a project in taskPane
@@ -288,46 +391,118 @@ func (pane *TaskPane) LoadProjectTasks(project model.Project) {
 
 	if tasks, err = taskRepo.GetAllByProject(project); err != nil && err != storm.ErrNotFound {
 		statusBar.showForSeconds("[red::]Error: "+err.Error(), 5)
+	} else if pane.showUnstarted {
+		pane.SetList(tasks)
 	} else {
+		var hidden int
+		tasks, hidden = startedTasks(tasks, time.Now())
 		pane.SetList(tasks)
+		if hidden > 0 {
+			statusBar.showForSeconds(fmt.Sprintf("[yellow]%d tasks hidden until their start date, press f to show", hidden), 5)
+		}
 	}
 
+	pane.listTitle = project.Title
 	pane.RemoveItem(pane.hint)
 	pane.AddItem(pane.newTask, 1, 0, false)
 	updateProjectHeader()
 }
 
-// LoadDynamicList loads tasks based on logic key
-func (pane *TaskPane) LoadDynamicList(logic string) {
+// dynamicListTasks finds tasks of a dynamic list (today, tomorrow, upcoming, available or unscheduled)
+// and its description. Lists of days have tasks due or starting on those days, ordered by due time.
+func dynamicListTasks(logic string) ([]model.Task, string, error) {
 	var tasks []model.Task
 	var err error
 
-	today := toDate(time.Now())
+	now := time.Now()
+	today := toDate(now)
 	zeroTime := time.Time{}
 	rangeDesc := ""
 
 	switch logic {
 	case "today":
-		tasks, err = pane.taskRepo.GetAllByDateRange(zeroTime, today)
+		tasks, err = datedTasks(zeroTime, today, today)
 		rangeDesc = "Today (and overdue)"
 
 	case "tomorrow":
 		tomorrow := today.AddDate(0, 0, 1)
-		tasks, err = pane.taskRepo.GetAllByDate(tomorrow)
+		tasks, err = datedTasks(tomorrow, tomorrow, tomorrow)
 		rangeDesc = "Tomorrow"
 
 	case "upcoming":
-		week := today.Add(7 * 24 * time.Hour)
-		tasks, err = pane.taskRepo.GetAllByDateRange(today, week)
+		week := today.AddDate(0, 0, 7)
+		tasks, err = datedTasks(today, today, week)
 		rangeDesc = "Upcoming (next 7 days)"
 
+	case "available":
+		if tasks, err = taskRepo.GetAll(); err == nil {
+			tasks = openTasks(tasks)
+		}
+		tasks, _ = startedTasks(tasks, now)
+		repository.SortTasks(tasks)
+		rangeDesc = "Available (open and started tasks)"
+
 	case "unscheduled":
-		tasks, err = pane.taskRepo.GetAllByDate(zeroTime)
+		tasks, err = taskRepo.GetAllByDate(zeroTime)
+		tasks, _ = startedTasks(tasks, now)
+		repository.SortTasks(tasks)
 		rangeDesc = "Unscheduled (task with no due date) "
+
+	default:
+		err = fmt.Errorf("unknown list %q, use today, tomorrow, upcoming, available or unscheduled", logic)
+	}
+
+	if err == nil && len(tasks) == 0 {
+		err = storm.ErrNotFound
+	}
+	return tasks, rangeDesc, err
+}
+
+// datedTasks finds tasks due from dueFrom to `to` and tasks starting from startFrom to `to`, ordered by due time
+func datedTasks(dueFrom, startFrom, to time.Time) ([]model.Task, error) {
+	tasks, err := taskRepo.GetAllByDateRange(dueFrom, to)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	starting, err := taskRepo.GetAllByStartDateRange(startFrom, to)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	listed := make(map[int64]bool, len(tasks))
+	for _, task := range tasks {
+		listed[task.ID] = true
+	}
+	for _, task := range starting {
+		if !listed[task.ID] {
+			tasks = append(tasks, task)
+		}
 	}
 
+	repository.SortTasks(tasks)
+	repository.SortByDue(tasks, time.Local)
+	return tasks, nil
+}
+
+// openTasks keeps the tasks not completed
+func openTasks(tasks []model.Task) []model.Task {
+	var open []model.Task
+	for _, task := range tasks {
+		if !task.Completed {
+			open = append(open, task)
+		}
+	}
+
+	return open
+}
+
+// LoadDynamicList loads tasks based on logic key
+func (pane *TaskPane) LoadDynamicList(logic string) {
+	tasks, rangeDesc, err := dynamicListTasks(logic)
+
 	projectPane.activeProject = nil
 	taskPane.ClearList()
+	pane.listTitle = strings.TrimSpace(rangeDesc)
 
 	if err == storm.ErrNotFound {
 		statusBar.showForSeconds("[yellow]No Task in list - "+rangeDesc, 5)
@@ -335,7 +510,6 @@ func (pane *TaskPane) LoadDynamicList(logic string) {
 	} else if err != nil {
 		statusBar.showForSeconds("[red]Error: "+err.Error(), 5)
 	} else {
-		sort.Slice(tasks, func(i, j int) bool { return tasks[i].ProjectID < tasks[j].ProjectID })
 		pane.SetList(tasks)
 		app.SetFocus(taskPane)
 
@@ -347,6 +521,44 @@ func (pane *TaskPane) LoadDynamicList(logic string) {
 	removeThirdCol()
 }
 
+// removeTasks takes tasks of the IDs out of the list (e.g. moved to another project or Trash)
+func (pane *TaskPane) removeTasks(ids map[int64]bool) {
+	if pane.activeTask != nil && ids[pane.activeTask.ID] {
+		pane.activeTask = nil
+		removeThirdCol()
+		if projectPane.GetActiveProject() != nil {
+			contents.AddItem(projectDetailPane, 25, 0, false)
+		}
+		app.SetFocus(pane)
+	}
+
+	// Selection stays, or goes to the next remaining task (previous one, if at the end)
+	var selectedID int64
+	current := pane.list.GetCurrentItem()
+	for i := current; i >= 0 && i < len(pane.tasks) && selectedID == 0; i++ {
+		if !ids[pane.tasks[i].ID] {
+			selectedID = pane.tasks[i].ID
+		}
+	}
+	for i := current - 1; i >= 0 && i < len(pane.tasks) && selectedID == 0; i-- {
+		if !ids[pane.tasks[i].ID] {
+			selectedID = pane.tasks[i].ID
+		}
+	}
+
+	// A new slice, as the active task points to the current one
+	remaining := make([]model.Task, 0, len(pane.tasks))
+	for _, task := range pane.tasks {
+		if ids[task.ID] {
+			delete(pane.marked, task.ID)
+			continue
+		}
+		remaining = append(remaining, task)
+	}
+	pane.tasks = remaining
+	pane.redrawList(selectedID)
+}
+
 // ActivateTask marks a task as currently active and loads in TaskDetailPane
 func (pane *TaskPane) ActivateTask(idx int) {
 	removeThirdCol()
@@ -356,22 +568,52 @@ func (pane *TaskPane) ActivateTask(idx int) {
 	contents.AddItem(taskDetailPane, 0, 3, false)
 }
 
-// ClearCompletedTasks removes tasks from current list that are in completed state
+// ClearCompletedTasks moves tasks of current list that are in completed state to Trash
 func (pane *TaskPane) ClearCompletedTasks() {
-	count := 0
+	deletedAt := time.Now()
+	var cleared []model.Task
 	for i, task := range pane.tasks {
-		if task.Completed && pane.taskRepo.Delete(&pane.tasks[i]) == nil {
-			pane.list.RemoveItem(i)
-			count++
+		if task.Completed && pane.taskRepo.SoftDelete(&pane.tasks[i], deletedAt) == nil {
+			cleared = append(cleared, pane.tasks[i])
 		}
 	}
 
-	statusBar.showForSeconds(fmt.Sprintf("[yellow]%d tasks cleared!", count), 5)
+	if len(cleared) > 0 {
+		pushUndo(fmt.Sprintf("clearing %d completed tasks", len(cleared)), func() error {
+			for i := range cleared {
+				if err := taskRepo.Restore(&cleared[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	if project := projectPane.GetActiveProject(); project != nil {
+		pane.LoadProjectTasks(*project)
+	}
+	statusBar.showForSeconds(fmt.Sprintf("[yellow]%d tasks moved to Trash. Press u to undo.", len(cleared)), 5)
+}
+
+// ExportList exports all tasks of current project or dynamic list
+func (pane *TaskPane) ExportList() {
+	if len(pane.tasks) == 0 {
+		statusBar.showForSeconds("[yellow]No task to export", 3)
+		return
+	}
+
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		statusBar.showForSeconds("[red]Could not load projects: "+err.Error(), 5)
+		return
+	}
+
+	showExportPicker(export.NewDocument(pane.listTitle, pane.tasks, projects))
 }
 
 // ReloadCurrentTask Loads the current task - in Task details and listing
 func (pane *TaskPane) ReloadCurrentTask() {
-	pane.list.SetItemText(pane.list.GetCurrentItem(), makeTaskListingTitle(*pane.activeTask), "")
+	pane.list.SetItemText(pane.list.GetCurrentItem(), pane.listingTitle(pane.list.GetCurrentItem()), "")
 	taskDetailPane.SetTask(pane.activeTask)
 }
 
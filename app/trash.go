@@ -0,0 +1,269 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/ajaxray/geek-life/backup"
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// LoadTrash lists deleted projects and tasks in TaskPane, to restore or purge them
+func (pane *TaskPane) LoadTrash() {
+	projects, err := projectRepo.GetAllDeleted()
+	if err != nil {
+		statusBar.showForSeconds("[red]Could not load Trash: "+err.Error(), 5)
+		return
+	}
+	tasks, err := pane.taskRepo.GetAllDeleted()
+	if err != nil {
+		statusBar.showForSeconds("[red]Could not load Trash: "+err.Error(), 5)
+		return
+	}
+
+	projectPane.activeProject = nil
+	pane.ClearList()
+	pane.showingTrash = true
+	pane.trashProjects = projects
+
+	for _, project := range projects {
+		pane.list.AddItem(
+			fmt.Sprintf("[::d]%s[::-] Project: %s", project.DeletedAt.Format(dateLayoutISO), project.Title),
+			"", 0, nil,
+		)
+	}
+
+	for _, task := range tasks {
+		// Tasks deleted along with their project are restored and purged with it
+		if deletedWithProject(task, projects) {
+			continue
+		}
+
+		pane.tasks = append(pane.tasks, task)
+		pane.list.AddItem(
+			fmt.Sprintf("[::d]%s[::-] %s", task.DeletedAt.Format(dateLayoutISO), getTaskTitleWithTicket(task)),
+			"", 0, nil,
+		)
+	}
+
+	pane.RemoveItem(pane.hint)
+	updateProjectHeaderWithContext("trash")
+	removeThirdCol()
+	app.SetFocus(pane)
+
+	if pane.list.GetItemCount() == 0 {
+		statusBar.showForSeconds("[yellow]Trash is empty", 5)
+	} else {
+		statusBar.showForSeconds("[yellow]Press r to restore, x to delete forever", 5)
+	}
+}
+
+func (pane *TaskPane) handleTrashShortcuts(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Rune() {
+	case 'r':
+		pane.restoreTrashItem(pane.list.GetCurrentItem())
+		return nil
+	case 'x':
+		pane.purgeTrashItemWithConfirmation(pane.list.GetCurrentItem())
+		return nil
+	case 'n':
+		// No new tasks in Trash
+		return nil
+	}
+
+	switch event.Key() {
+	case tcell.KeyDelete:
+		pane.purgeTrashItemWithConfirmation(pane.list.GetCurrentItem())
+		return nil
+	case tcell.KeyCtrlB, tcell.KeyCtrlJ:
+		// Ticket actions are not available for deleted items
+		return nil
+	}
+
+	return event
+}
+
+// trashItem finds the project or the task shown at idx of Trash listing
+func (pane *TaskPane) trashItem(idx int) (*model.Project, *model.Task) {
+	if idx < 0 {
+		return nil, nil
+	} else if idx < len(pane.trashProjects) {
+		return &pane.trashProjects[idx], nil
+	} else if idx-len(pane.trashProjects) < len(pane.tasks) {
+		return nil, &pane.tasks[idx-len(pane.trashProjects)]
+	}
+
+	return nil, nil
+}
+
+func (pane *TaskPane) restoreTrashItem(idx int) {
+	project, task := pane.trashItem(idx)
+
+	var err error
+	var title string
+	switch {
+	case project != nil:
+		title = project.Title
+		err = restoreProject(*project)
+	case task != nil:
+		title = task.Title
+		err = restoreTask(*task)
+	default:
+		return
+	}
+
+	if err != nil {
+		statusBar.showForSeconds("[red]Could not restore: "+err.Error(), 5)
+		return
+	}
+
+	projectPane.loadListItems(false)
+	pane.LoadTrash()
+	statusBar.showForSeconds("[lime]Restored: "+title, 5)
+}
+
+func (pane *TaskPane) purgeTrashItemWithConfirmation(idx int) {
+	project, task := pane.trashItem(idx)
+
+	var message string
+	var purge func() error
+	switch {
+	case project != nil:
+		message = fmt.Sprintf("Delete project \"%s\" and its tasks forever?\n\nThis can not be undone.", project.Title)
+		purge = func() error { return purgeProject(*project) }
+	case task != nil:
+		message = fmt.Sprintf("Delete task \"%s\" forever?\n\nThis can not be undone.", task.Title)
+		purge = func() error { return taskRepo.Delete(task) }
+	default:
+		return
+	}
+
+	AskYesNo(message, func() {
+		if err := backupBeforePurge(); err != nil {
+			statusBar.showForSeconds("[red]Could not backup before purging: "+err.Error(), 5)
+			return
+		}
+
+		if err := purge(); err != nil {
+			statusBar.showForSeconds("[red]Could not delete: "+err.Error(), 5)
+			return
+		}
+
+		// Purged records can not be brought back by undo anymore
+		undoStack = nil
+		pane.LoadTrash()
+		statusBar.showForSeconds("[lime]Deleted forever", 5)
+	})
+}
+
+// restoreProject takes a project out of Trash, along with the tasks deleted with it
+func restoreProject(project model.Project) error {
+	if project.DeletedAt != nil {
+		if err := taskRepo.RestoreAllByProjectID(project.ID, *project.DeletedAt); err != nil {
+			return err
+		}
+	}
+
+	return projectRepo.Restore(&project)
+}
+
+// restoreTask takes a task out of Trash. A deleted project of the task is restored too.
+func restoreTask(task model.Task) error {
+	project, err := projectRepo.GetByID(task.ProjectID)
+	if err == nil && project.IsDeleted() {
+		if err := projectRepo.Restore(&project); err != nil {
+			return err
+		}
+	}
+
+	return taskRepo.Restore(&task)
+}
+
+// purgeProject permanently deletes a project and all of its tasks
+func purgeProject(project model.Project) error {
+	if err := taskRepo.DeleteAllByProjectID(project.ID); err != nil {
+		return err
+	}
+
+	return projectRepo.Delete(&project)
+}
+
+// purgeExpiredTrash permanently deletes items that are in Trash for longer than TRASH_RETENTION_DAYS (default 30).
+// Setting TRASH_RETENTION_DAYS=0 keeps deleted items until purged manually.
+func purgeExpiredTrash() {
+	retentionDays := util.GetEnvInt("TRASH_RETENTION_DAYS", 30)
+	if retentionDays <= 0 {
+		return
+	}
+	expiry := time.Now().AddDate(0, 0, -retentionDays)
+
+	projects, err := projectRepo.GetAllDeleted()
+	if err != nil {
+		util.LogError("Could not load deleted projects: %v", err)
+		return
+	}
+	tasks, err := taskRepo.GetAllDeleted()
+	if err != nil {
+		util.LogError("Could not load deleted tasks: %v", err)
+		return
+	}
+
+	var expiredProjects []model.Project
+	for _, project := range projects {
+		if project.DeletedAt.Before(expiry) {
+			expiredProjects = append(expiredProjects, project)
+		}
+	}
+	var expiredTasks []model.Task
+	for _, task := range tasks {
+		if task.DeletedAt.Before(expiry) && !deletedWithProject(task, expiredProjects) {
+			expiredTasks = append(expiredTasks, task)
+		}
+	}
+
+	if len(expiredProjects) == 0 && len(expiredTasks) == 0 {
+		return
+	}
+
+	if err := backupBeforePurge(); err != nil {
+		util.LogError("Could not backup before purging Trash: %v", err)
+		return
+	}
+
+	for _, project := range expiredProjects {
+		util.LogIfError(purgeProject(project), "Could not purge project %d", project.ID)
+	}
+	for i := range expiredTasks {
+		util.LogIfError(taskRepo.Delete(&expiredTasks[i]), "Could not purge task %d", expiredTasks[i].ID)
+	}
+
+	util.LogInfo(
+		"Purged %d projects and %d tasks deleted more than %d days ago",
+		len(expiredProjects),
+		len(expiredTasks),
+		retentionDays,
+	)
+}
+
+// deletedWithProject checks if a task went to Trash along with one of the deleted projects
+func deletedWithProject(task model.Task, deletedProjects []model.Project) bool {
+	for _, project := range deletedProjects {
+		if project.ID == task.ProjectID && project.DeletedAt.Equal(*task.DeletedAt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backupBeforePurge takes a snapshot of the database, as purged items can't be restored from Trash
+func backupBeforePurge() error {
+	if db == nil {
+		return nil
+	}
+
+	return backup.Rotate(db, "pre-purge", backup.ConfigFromEnv())
+}
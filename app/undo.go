@@ -0,0 +1,57 @@
+package main
+
+// undoLimit is the number of destructive actions that can be undone
+const undoLimit = 20
+
+// undoEntry knows how to revert a destructive action
+type undoEntry struct {
+	description string
+	revert      func() error
+}
+
+var undoStack []undoEntry
+
+// pushUndo records a destructive action, so that it can be reverted with `u`
+func pushUndo(description string, revert func() error) {
+	undoStack = append(undoStack, undoEntry{description, revert})
+	if len(undoStack) > undoLimit {
+		undoStack = undoStack[len(undoStack)-undoLimit:]
+	}
+}
+
+// undoLastAction reverts the last recorded destructive action
+func undoLastAction() {
+	if len(undoStack) == 0 {
+		statusBar.showForSeconds("[yellow]Nothing to undo", 3)
+		return
+	}
+
+	entry := undoStack[len(undoStack)-1]
+	undoStack = undoStack[:len(undoStack)-1]
+
+	if err := entry.revert(); err != nil {
+		statusBar.showForSeconds("[red]Could not undo "+entry.description+": "+err.Error(), 5)
+		return
+	}
+
+	refreshAfterUndo()
+	statusBar.showForSeconds("[lime]Undone: "+entry.description, 5)
+}
+
+// refreshAfterUndo reloads the panes that may show reverted data
+func refreshAfterUndo() {
+	activeProject := projectPane.GetActiveProject()
+	showingTrash := taskPane.showingTrash
+
+	projectPane.loadListItems(false)
+
+	switch {
+	case showingTrash:
+		taskPane.LoadTrash()
+	case activeProject != nil:
+		if project, err := projectRepo.GetByID(activeProject.ID); err == nil && !project.IsDeleted() {
+			projectPane.activeProject = &project
+			taskPane.LoadProjectTasks(project)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/rivo/tview"
 
 	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/ticketmanager"
 	"github.com/ajaxray/geek-life/util"
 )
 
@@ -41,15 +42,6 @@ func makeLightTextInput(placeholder string) *tview.InputField {
 		SetFieldBackgroundColor(tcell.ColorLightBlue)
 }
 
-// If input text is a valid date, parse it. Or get current date
-func parseDateInputOrCurrent(inputText string) time.Time {
-	if dateTime, err := time.Parse(dateLayoutISO, inputText); err == nil {
-		return toDate(dateTime)
-	}
-
-	return toDate(time.Now())
-}
-
 func toDate(dateTime time.Time) time.Time {
 	return time.Date(dateTime.Year(), dateTime.Month(), dateTime.Day(), 0, 0, 0, 0, time.Local)
 }
@@ -65,7 +57,12 @@ func makeButton(label string, handler func()) *tview.Button {
 
 func ignoreKeyEvt() bool {
 	textInputs := []string{"*tview.InputField", "*femto.View"}
-	return util.InArray(reflect.TypeOf(app.GetFocus()).String(), textInputs)
+	if util.InArray(reflect.TypeOf(app.GetFocus()).String(), textInputs) {
+		return true
+	}
+
+	// Menus, prompts and pages shown over the layout handle their keys themselves
+	return !projectPane.HasFocus() && !taskPane.HasFocus() && !taskDetailPane.HasFocus() && !projectDetailPane.HasFocus()
 }
 
 // yetToImplement - to use as callback for unimplemented features
@@ -81,17 +78,12 @@ func removeThirdCol() {
 }
 
 func getTaskTitleColor(task model.Task) string {
-	colorName := "smokewhite"
-
-	if task.Completed {
-		colorName = "green"
-	} else if task.DueDate != 0 {
-		dayDiff := int(time.Until(time.Unix(task.DueDate, 0)).Hours() / 24)
-		if dayDiff == 0 {
-			colorName = "orange"
-		} else if dayDiff < 0 {
-			colorName = "red"
-		}
+	colorName := statusColor(task.CurrentStatus())
+
+	if now := time.Now(); task.IsOverdue(now) {
+		colorName = "red"
+	} else if !task.Completed && task.DueDay(time.Local).Equal(toDate(now)) {
+		colorName = "orange"
 	}
 
 	return colorName
@@ -99,9 +91,14 @@ func getTaskTitleColor(task model.Task) string {
 
 func makeTaskListingTitle(task model.Task) string {
 	checkbox := "[ []"
-	if task.Completed {
+	if task.CurrentStatus() == model.StatusCancelled {
+		checkbox = "[-[]"
+	} else if task.Completed {
 		checkbox = "[x[]"
 	}
+	if task.Pinned {
+		checkbox += " 📌"
+	}
 
 	prefix := ""
 	if projectPane.GetActiveProject() == nil {
@@ -111,18 +108,43 @@ func makeTaskListingTitle(task model.Task) string {
 	}
 
 	return fmt.Sprintf(
-		"[%s]%s %s%s",
+		"[%s]%s %s%s%s",
 		getTaskTitleColor(task),
 		checkbox,
 		prefix,
 		getTaskTitleWithTicket(task),
+		makeTaskTimingText(task),
 	)
 }
 
+// makeTaskTimingText shows the due time of a task and the start date, if not started yet
+func makeTaskTimingText(task model.Task) string {
+	text := ""
+	if dueTime := formatDueTime(task); dueTime != "" {
+		text += " [::d]@ " + dueTime + "[::-]"
+	}
+	if now := time.Now(); !task.Completed && !task.IsStarted(now) {
+		text += " [gray](starts " + task.StartDay(time.Local).Format("02 Jan") + ")"
+	}
+	if hasUpcomingReminder(task, time.Now()) {
+		text += " ⏰"
+	}
+
+	return text
+}
+
+// projectTicketManager finds the TicketManager of the provider a project is (or will be) linked with
+func projectTicketManager(
+	project model.Project,
+) (ticketmanager.TicketManager, ticketmanager.ProviderType, error) {
+	tm, err := tickets.Get(project.Provider)
+	return tm, tickets.Resolve(project.Provider), err
+}
+
 func getTaskTitleWithTicket(task model.Task) string {
 	ticket := fmt.Sprintf("%s [No Ticket]", task.Title)
-	if task.JiraID != "" {
-		ticket = fmt.Sprintf("%s [lime]Ticket: %s", task.Title, task.JiraID)
+	if task.IsLinked() {
+		ticket = fmt.Sprintf("%s [lime]Ticket: %s", task.Title, task.ExternalKey)
 	}
 	return ticket
 }
@@ -0,0 +1,258 @@
+// Package backup creates, rotates and restores snapshots of the storm (bolt) database file.
+package backup
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ajaxray/geek-life/util"
+)
+
+const (
+	timestampLayout = "20060102-150405"
+	snapshotExt     = ".db"
+)
+
+// Config controls automatic snapshots and their retention
+type Config struct {
+	OnStartup bool          // Take a snapshot when the app starts
+	Interval  time.Duration // Take a snapshot periodically while the app is running. 0 disables.
+	Keep      int           // Number of newest snapshots to keep. 0 keeps all.
+	MaxAge    time.Duration // Remove snapshots older than this. 0 keeps regardless of age.
+}
+
+// ConfigFromEnv reads backup configuration from environment variables
+func ConfigFromEnv() Config {
+	return Config{
+		OnStartup: util.GetEnvInt("BACKUP_ON_STARTUP", 1) == 1,
+		Interval:  time.Duration(util.GetEnvInt("BACKUP_INTERVAL_HOURS", 24)) * time.Hour,
+		Keep:      util.GetEnvInt("BACKUP_KEEP", 10),
+		MaxAge:    time.Duration(util.GetEnvInt("BACKUP_MAX_AGE_DAYS", 30)) * 24 * time.Hour,
+	}
+}
+
+// Snapshot is a backup file of the database
+type Snapshot struct {
+	Name      string
+	Path      string
+	Reason    string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// Dir returns the directory where snapshots of the database are kept.
+// Default is a "backups" sibling of the DB file (~/.geek-life/backups), BACKUP_DIR overrides it.
+func Dir(db *storm.DB) string {
+	return DirFor(db.Bolt.Path())
+}
+
+// DirFor returns the snapshot directory of a database file path
+func DirFor(dbPath string) string {
+	if dir := util.GetEnvStr("BACKUP_DIR", ""); dir != "" {
+		return dir
+	}
+
+	return filepath.Join(filepath.Dir(dbPath), "backups")
+}
+
+// Create writes a consistent snapshot of the open database. Reason becomes part of the file name.
+func Create(db *storm.DB, reason string) (string, error) {
+	dir := Dir(db)
+	util.CreateDirIfNotExist(dir)
+
+	name := fmt.Sprintf("%s-%s%s", time.Now().Format(timestampLayout), reason, snapshotExt)
+	path := filepath.Join(dir, name)
+
+	err := db.Bolt.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(path, 0600)
+	})
+	if err != nil {
+		_ = os.Remove(path)
+		return "", err
+	}
+
+	return path, nil
+}
+
+// List returns snapshots found in dir, newest first
+func List(dir string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, entry := range entries {
+		snapshot, ok := parseSnapshotName(entry.Name())
+		if entry.IsDir() || !ok {
+			continue
+		}
+
+		snapshot.Path = filepath.Join(dir, entry.Name())
+		if info, err := entry.Info(); err == nil {
+			snapshot.Size = info.Size()
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].CreatedAt.After(snapshots[j].CreatedAt) })
+	return snapshots, nil
+}
+
+// Prune removes snapshots exceeding the retention rules of config. The newest snapshot is always kept.
+func Prune(dir string, config Config) ([]Snapshot, error) {
+	snapshots, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []Snapshot
+	for i, snapshot := range snapshots {
+		if i == 0 {
+			continue
+		}
+
+		tooMany := config.Keep > 0 && i >= config.Keep
+		tooOld := config.MaxAge > 0 && time.Since(snapshot.CreatedAt) > config.MaxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+
+		if err := os.Remove(snapshot.Path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, snapshot)
+	}
+
+	return removed, nil
+}
+
+// Find locates a snapshot in dir by its file name (with or without extension) or path
+func Find(dir, nameOrPath string) (Snapshot, error) {
+	snapshots, err := List(dir)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshot.Name == nameOrPath || snapshot.Name == nameOrPath+snapshotExt || snapshot.Path == nameOrPath {
+			return snapshot, nil
+		}
+	}
+
+	return Snapshot{}, fmt.Errorf("snapshot %s not found in %s", nameOrPath, dir)
+}
+
+// Verify opens a snapshot read-only and runs bolt's consistency check on it
+func Verify(path string) error {
+	snapshotDB, err := bolt.Open(path, 0600, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err != nil {
+		return fmt.Errorf("could not open snapshot: %w", err)
+	}
+	defer snapshotDB.Close()
+
+	return snapshotDB.View(func(tx *bolt.Tx) error {
+		for err := range tx.Check() {
+			return fmt.Errorf("snapshot is corrupted: %w", err)
+		}
+		return nil
+	})
+}
+
+// Restore verifies a snapshot and replaces the database file with it.
+// The database must be closed before restoring.
+func Restore(snapshotPath, dbPath string) error {
+	if err := Verify(snapshotPath); err != nil {
+		return err
+	}
+
+	source, err := os.Open(snapshotPath)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	// Write next to the DB file first, so that a failed copy never leaves a half written database
+	tmpPath := dbPath + ".restoring"
+	target, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(target, source); err != nil {
+		target.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := target.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, dbPath)
+}
+
+// Schedule takes a snapshot (and prunes old ones) every config.Interval until stop is called
+func Schedule(db *storm.DB, config Config, onError func(error)) (stop func()) {
+	if config.Interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	ticker := time.NewTicker(config.Interval)
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if err := Rotate(db, "scheduled", config); err != nil {
+					onError(err)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// Rotate creates a snapshot and removes the ones exceeding retention rules
+func Rotate(db *storm.DB, reason string, config Config) error {
+	if _, err := Create(db, reason); err != nil {
+		return err
+	}
+
+	_, err := Prune(Dir(db), config)
+	return err
+}
+
+// parseSnapshotName extracts creation time and reason from names like 20240131-183000-startup.db
+func parseSnapshotName(name string) (Snapshot, bool) {
+	if !strings.HasSuffix(name, snapshotExt) || len(name) < len(timestampLayout)+len(snapshotExt) {
+		return Snapshot{}, false
+	}
+
+	createdAt, err := time.ParseInLocation(timestampLayout, name[:len(timestampLayout)], time.Local)
+	if err != nil {
+		return Snapshot{}, false
+	}
+
+	reason := strings.TrimSuffix(name[len(timestampLayout):], snapshotExt)
+	return Snapshot{
+		Name:      name,
+		Reason:    strings.TrimPrefix(reason, "-"),
+		CreatedAt: createdAt,
+	}, true
+}
@@ -0,0 +1,232 @@
+// Package export renders tasks in various formats (Markdown, plain text, Jira wiki markup, HTML, CSV)
+// using Go templates. Custom formats can be added as templates in ~/.geek-life/templates/export
+package export
+
+import (
+	"bytes"
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/ticketmanager"
+	"github.com/ajaxray/geek-life/util"
+)
+
+const templateExt = ".tmpl"
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// Task is a task, as seen by export templates
+type Task struct {
+	UUID        string
+	Link        string // Deep link, opens the task in geek-life
+	Title       string
+	Details     string
+	Project     string
+	Completed   bool
+	DueDate     *time.Time
+	DueTime     string // Local time of the due day, e.g. 15:30. Empty if due any time of the day.
+	StartDate   *time.Time
+	CreatedAt   *time.Time
+	CompletedAt *time.Time
+	TicketKey   string
+	TicketURL   string
+}
+
+// Document is the data given to export templates
+type Document struct {
+	Title       string // Task title for single task, project or list name otherwise
+	Single      bool   // Exporting a single task
+	Tasks       []Task
+	GeneratedAt time.Time
+}
+
+// Format is an export template
+type Format struct {
+	Name      string // e.g. markdown
+	Extension string // File extension of exported content, e.g. md
+	Custom    bool   // Loaded from user template directory
+	path      string
+}
+
+// NewTaskDocument prepares a single task for export
+func NewTaskDocument(task model.Task, project model.Project) Document {
+	return Document{
+		Title:       task.Title,
+		Single:      true,
+		Tasks:       []Task{makeTask(task, project)},
+		GeneratedAt: time.Now(),
+	}
+}
+
+// NewDocument prepares a list of tasks (of a project or dynamic list) for export
+func NewDocument(title string, tasks []model.Task, projects []model.Project) Document {
+	projectByID := make(map[int64]model.Project, len(projects))
+	for _, project := range projects {
+		projectByID[project.ID] = project
+	}
+
+	doc := Document{Title: title, GeneratedAt: time.Now()}
+	for _, task := range tasks {
+		doc.Tasks = append(doc.Tasks, makeTask(task, projectByID[task.ProjectID]))
+	}
+
+	return doc
+}
+
+// Formats lists built-in formats and the ones in user template directory, sorted by name.
+// A user template with the name of a built-in format replaces it.
+func Formats() []Format {
+	formats := make(map[string]Format)
+
+	entries, _ := builtinTemplates.ReadDir("templates")
+	for _, entry := range entries {
+		if format, ok := parseTemplateName(entry.Name()); ok {
+			format.path = "templates/" + entry.Name()
+			formats[format.Name] = format
+		}
+	}
+
+	entries, _ = os.ReadDir(TemplateDir())
+	for _, entry := range entries {
+		if format, ok := parseTemplateName(entry.Name()); ok && !entry.IsDir() {
+			format.Custom = true
+			format.path = filepath.Join(TemplateDir(), entry.Name())
+			formats[format.Name] = format
+		}
+	}
+
+	list := make([]Format, 0, len(formats))
+	for _, format := range formats {
+		list = append(list, format)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list
+}
+
+// FindFormat finds an export format by name
+func FindFormat(name string) (Format, error) {
+	var names []string
+	for _, format := range Formats() {
+		if format.Name == name {
+			return format, nil
+		}
+		names = append(names, format.Name)
+	}
+
+	return Format{}, fmt.Errorf("unknown export format %q, available: %s", name, strings.Join(names, ", "))
+}
+
+// Render exports doc in the named format
+func Render(formatName string, doc Document) (string, error) {
+	format, err := FindFormat(formatName)
+	if err != nil {
+		return "", err
+	}
+
+	var content []byte
+	if format.Custom {
+		content, err = os.ReadFile(format.path)
+	} else {
+		content, err = builtinTemplates.ReadFile(format.path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not read template of %s: %w", format.Name, err)
+	}
+
+	tmpl, err := template.New(format.Name).Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return "", fmt.Errorf("invalid template of %s: %w", format.Name, err)
+	}
+
+	var output bytes.Buffer
+	if err := tmpl.Execute(&output, doc); err != nil {
+		return "", fmt.Errorf("could not export as %s: %w", format.Name, err)
+	}
+
+	return output.String(), nil
+}
+
+// TemplateDir is the directory of custom export templates, e.g. ~/.geek-life/templates/export/confluence.html.tmpl
+func TemplateDir() string {
+	return filepath.Join(util.TemplateDir(), "export")
+}
+
+var templateFuncs = template.FuncMap{
+	"date": func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format("2006-01-02")
+	},
+	"repeat": strings.Repeat,
+	// indent prefixes every line of text with 4 spaces
+	"indent": func(text string) string {
+		return "    " + strings.ReplaceAll(strings.TrimRight(text, "\n"), "\n", "\n    ")
+	},
+	// csv quotes a value for a CSV field
+	"csv": func(value string) string {
+		var field bytes.Buffer
+		writer := csv.NewWriter(&field)
+		_ = writer.Write([]string{value})
+		writer.Flush()
+		return strings.TrimRight(field.String(), "\r\n")
+	},
+}
+
+func makeTask(task model.Task, project model.Project) Task {
+	exported := Task{
+		UUID:        task.UUID,
+		Link:        util.TaskLink(task.UUID),
+		Title:       task.Title,
+		Details:     task.Details,
+		Project:     project.Title,
+		Completed:   task.Completed,
+		CompletedAt: task.CompletedAt,
+		TicketKey:   task.ExternalKey,
+	}
+	if task.DueDate != 0 {
+		due := task.DueDay(time.Local)
+		exported.DueDate = &due
+		if task.DueTime != "" {
+			exported.DueTime = task.DueAt(time.Local).In(time.Local).Format("15:04")
+		}
+	}
+	if task.StartDate != 0 {
+		start := task.StartDay(time.Local)
+		exported.StartDate = &start
+	}
+	if !task.CreatedAt.IsZero() {
+		createdAt := task.CreatedAt
+		exported.CreatedAt = &createdAt
+	}
+	if task.IsLinked() {
+		exported.TicketURL = ticketmanager.LinkURL(task.ExternalLink)
+	}
+
+	return exported
+}
+
+// parseTemplateName finds format name and extension from template file names like markdown.md.tmpl
+func parseTemplateName(fileName string) (Format, bool) {
+	if !strings.HasSuffix(fileName, templateExt) {
+		return Format{}, false
+	}
+
+	name := strings.TrimSuffix(fileName, templateExt)
+	extension := "txt"
+	if dot := strings.Index(name, "."); dot > 0 {
+		name, extension = name[:dot], name[dot+1:]
+	}
+
+	return Format{Name: name, Extension: extension}, name != ""
+}
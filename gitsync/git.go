@@ -0,0 +1,225 @@
+package gitsync
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// lastSyncedFile keeps the last synced commit, in .git as it's about the local copy
+const lastSyncedFile = "geek-life-last-sync"
+
+// Repo is a git repository (working tree) holding a snapshot
+type Repo struct {
+	Dir string
+}
+
+// Open makes a Repo of dir, initialising a git repository if not exists
+func Open(dir string) (*Repo, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	repo := &Repo{Dir: dir}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
+		if _, err := repo.git("init", "--quiet"); err != nil {
+			return nil, err
+		}
+	}
+
+	return repo, nil
+}
+
+// HasCommits checks if anything is committed yet
+func (r *Repo) HasCommits() bool {
+	return r.RefExists("HEAD")
+}
+
+// RefExists checks if a revision (branch, commit etc.) exists
+func (r *Repo) RefExists(ref string) bool {
+	_, err := r.git("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	return err == nil
+}
+
+// Branch is the name of current branch
+func (r *Repo) Branch() (string, error) {
+	return r.git("symbolic-ref", "--short", "HEAD")
+}
+
+// HasRemote checks if the named remote is configured
+func (r *Repo) HasRemote(remote string) bool {
+	_, err := r.git("remote", "get-url", remote)
+	return err == nil
+}
+
+// Fetch downloads commits of remote
+func (r *Repo) Fetch(remote string) error {
+	_, err := r.git("fetch", "--quiet", remote)
+	return err
+}
+
+// Push uploads current branch to remote
+func (r *Repo) Push(remote, branch string) error {
+	_, err := r.git("push", "--quiet", remote, "HEAD:"+branch)
+	return err
+}
+
+// ReadFiles reads the files committed in ref. Empty ref gives no files.
+func (r *Repo) ReadFiles(ref string) (Files, error) {
+	files := make(Files)
+	if ref == "" {
+		return files, nil
+	}
+
+	listing, err := r.git("ls-tree", "-r", "-z", ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries are "<mode> blob <hash>\t<path>"
+	var hashes, paths []string
+	for _, entry := range strings.Split(listing, "\x00") {
+		info, name, ok := strings.Cut(entry, "\t")
+		if parts := strings.Fields(info); ok && len(parts) == 3 && parts[1] == "blob" {
+			hashes = append(hashes, parts[2])
+			paths = append(paths, name)
+		}
+	}
+	if len(hashes) == 0 {
+		return files, nil
+	}
+
+	cmd := exec.Command("git", "cat-file", "--batch")
+	cmd.Dir = r.Dir
+	cmd.Stdin = strings.NewReader(strings.Join(hashes, "\n") + "\n")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+
+	// Output is "<hash> blob <size>\n<content>\n" for every object
+	reader := bufio.NewReader(bytes.NewReader(output))
+	for _, name := range paths {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		headerFields := strings.Fields(header)
+		size, err := strconv.Atoi(headerFields[len(headerFields)-1])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected git cat-file output %q", header)
+		}
+
+		content := make([]byte, size+1)
+		if _, err := io.ReadFull(reader, content); err != nil {
+			return nil, err
+		}
+		files[name] = content[:size]
+	}
+
+	return files, nil
+}
+
+// WriteFiles replaces the files in dirs of the working tree with files. Unchanged files are not touched.
+func (r *Repo) WriteFiles(files Files, dirs ...string) error {
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(filepath.Join(r.Dir, dir))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		for _, entry := range entries {
+			name := filepath.ToSlash(filepath.Join(dir, entry.Name()))
+			if _, keep := files[name]; !keep && !entry.IsDir() {
+				if err := os.Remove(filepath.Join(r.Dir, name)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for name, content := range files {
+		path := filepath.Join(r.Dir, filepath.FromSlash(name))
+		if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CommitAll commits all changes of the working tree. Returns false if there was nothing to commit.
+func (r *Repo) CommitAll(message string) (bool, error) {
+	if _, err := r.git("add", "--all"); err != nil {
+		return false, err
+	}
+	if _, err := r.git("diff", "--cached", "--quiet"); err == nil && r.HasCommits() {
+		return false, nil
+	}
+
+	_, err := r.git("commit", "--quiet", "--allow-empty", "-m", message)
+	return err == nil, err
+}
+
+// Head is the commit hash of HEAD, empty if nothing is committed yet
+func (r *Repo) Head() string {
+	hash, err := r.git("rev-parse", "--verify", "--quiet", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return hash
+}
+
+// Reset moves current branch and working tree to ref, dropping local commits and changes
+func (r *Repo) Reset(ref string) error {
+	_, err := r.git("reset", "--quiet", "--hard", ref)
+	return err
+}
+
+// LastSynced is the commit last synced with local data, empty if never synced
+func (r *Repo) LastSynced() string {
+	content, err := os.ReadFile(filepath.Join(r.Dir, ".git", lastSyncedFile))
+	if err != nil {
+		return ""
+	}
+
+	hash := strings.TrimSpace(string(content))
+	if !r.RefExists(hash) {
+		return ""
+	}
+	return hash
+}
+
+// SetLastSynced remembers the commit synced with local data
+func (r *Repo) SetLastSynced(hash string) error {
+	return os.WriteFile(filepath.Join(r.Dir, ".git", lastSyncedFile), []byte(hash+"\n"), 0644)
+}
+
+func (r *Repo) git(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.Dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], message)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
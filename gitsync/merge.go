@@ -0,0 +1,127 @@
+package gitsync
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MergeResult is a merged snapshot with the number of fields changed on both sides differently
+type MergeResult struct {
+	Snapshot  Snapshot
+	Conflicts int
+}
+
+// Merge makes a three-way merge of snapshots diverged from base, record by record (matched by UUID),
+// and field by field in records changed on both sides:
+//   - A field changed on one side takes that change
+//   - A field changed differently on both sides takes the value of the record updated last (ours if unknown)
+//   - A record deleted on one side is deleted, unless it has been changed on the other side
+func Merge(base, ours, theirs Snapshot) MergeResult {
+	result := MergeResult{Snapshot: newSnapshot()}
+	result.Conflicts += mergeRecords(base.Projects, ours.Projects, theirs.Projects, result.Snapshot.Projects)
+	result.Conflicts += mergeRecords(base.Tasks, ours.Tasks, theirs.Tasks, result.Snapshot.Tasks)
+
+	return result
+}
+
+func mergeRecords(base, ours, theirs, merged map[string]Record) int {
+	conflicts := 0
+	for uuid := range uuids(ours, theirs) {
+		baseRecord, inBase := base[uuid]
+		ourRecord, inOurs := ours[uuid]
+		theirRecord, inTheirs := theirs[uuid]
+
+		switch {
+		case !inTheirs:
+			// Added by us, or deleted by them
+			if !inBase || !Equivalent(ourRecord, baseRecord) {
+				merged[uuid] = ourRecord
+			}
+		case !inOurs:
+			if !inBase || !Equivalent(theirRecord, baseRecord) {
+				merged[uuid] = theirRecord
+			}
+		default:
+			record, recordConflicts := mergeFields(baseRecord, ourRecord, theirRecord)
+			merged[uuid] = record
+			conflicts += recordConflicts
+		}
+	}
+
+	return conflicts
+}
+
+func mergeFields(base, ours, theirs Record) (Record, int) {
+	merged := make(Record)
+	conflicts := 0
+	theirsIsNewer := updatedAt(theirs).After(updatedAt(ours))
+
+	for key := range fields(ours, theirs) {
+		baseValue, inBase := base[key]
+		ourValue, inOurs := ours[key]
+		theirValue, inTheirs := theirs[key]
+
+		var value json.RawMessage
+		switch {
+		case key == keyUpdatedAt:
+			value = ourValue
+			if theirsIsNewer {
+				value = theirValue
+			}
+		case inOurs == inTheirs && sameJSON(ourValue, theirValue):
+			value = ourValue
+		case inOurs == inBase && sameJSON(ourValue, baseValue):
+			value = theirValue
+		case inTheirs == inBase && sameJSON(theirValue, baseValue):
+			value = ourValue
+		default:
+			conflicts++
+			value = ourValue
+			if theirsIsNewer {
+				value = theirValue
+			}
+		}
+
+		// Missing (omitted empty) value stays missing
+		if value != nil {
+			merged[key] = value
+		}
+	}
+
+	return merged, conflicts
+}
+
+func updatedAt(record Record) time.Time {
+	var t time.Time
+	if raw, ok := record[keyUpdatedAt]; ok {
+		_ = json.Unmarshal(raw, &t)
+	}
+
+	return t
+}
+
+// uuids lists UUIDs of records in a or b
+func uuids(a, b map[string]Record) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+	for key := range a {
+		keys[key] = true
+	}
+	for key := range b {
+		keys[key] = true
+	}
+
+	return keys
+}
+
+// fields lists keys of fields in a or b
+func fields(a, b Record) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+	for key := range a {
+		keys[key] = true
+	}
+	for key := range b {
+		keys[key] = true
+	}
+
+	return keys
+}
@@ -0,0 +1,192 @@
+package gitsync
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var (
+	baseTime  = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	ourTime   = baseTime.Add(time.Hour)
+	theirTime = baseTime.Add(2 * time.Hour)
+)
+
+func record(t *testing.T, updatedAt time.Time, fields map[string]interface{}) Record {
+	t.Helper()
+	r := Record{}
+	fields[keyUpdatedAt] = updatedAt
+	for key, value := range fields {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r[key] = raw
+	}
+	return r
+}
+
+func tasks(records map[string]Record) Snapshot {
+	snap := newSnapshot()
+	for uuid, r := range records {
+		snap.Tasks[uuid] = r
+	}
+	return snap
+}
+
+func field(t *testing.T, r Record, key string) interface{} {
+	t.Helper()
+	raw, ok := r[key]
+	if !ok {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestMergeDifferentFields(t *testing.T) {
+	base := record(t, baseTime, map[string]interface{}{"Title": "Write report", "Details": "", "Completed": false})
+	ours := record(t, ourTime, map[string]interface{}{"Title": "Write annual report", "Details": "", "Completed": false})
+	theirs := record(t, theirTime, map[string]interface{}{"Title": "Write report", "Details": "", "Completed": true})
+
+	result := Merge(tasks(map[string]Record{"a": base}), tasks(map[string]Record{"a": ours}), tasks(map[string]Record{"a": theirs}))
+
+	if result.Conflicts != 0 {
+		t.Errorf("Conflicts = %d, want 0", result.Conflicts)
+	}
+	merged := result.Snapshot.Tasks["a"]
+	if got := field(t, merged, "Title"); got != "Write annual report" {
+		t.Errorf("Title = %v, want our change", got)
+	}
+	if got := field(t, merged, "Completed"); got != true {
+		t.Errorf("Completed = %v, want their change", got)
+	}
+}
+
+func TestMergeSameField(t *testing.T) {
+	tests := []struct {
+		name       string
+		ourTime    time.Time
+		theirTime  time.Time
+		wantTitle  string
+		wantUpdate time.Time
+	}{
+		{"theirs updated last", ourTime, theirTime, "Their title", theirTime},
+		{"ours updated last", theirTime, ourTime, "Our title", theirTime},
+		{"same time keeps ours", ourTime, ourTime, "Our title", ourTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := record(t, baseTime, map[string]interface{}{"Title": "Title", "Details": "Same"})
+			ours := record(t, tt.ourTime, map[string]interface{}{"Title": "Our title", "Details": "Same"})
+			theirs := record(t, tt.theirTime, map[string]interface{}{"Title": "Their title", "Details": "Same"})
+
+			result := Merge(tasks(map[string]Record{"a": base}), tasks(map[string]Record{"a": ours}), tasks(map[string]Record{"a": theirs}))
+
+			if result.Conflicts != 1 {
+				t.Errorf("Conflicts = %d, want 1", result.Conflicts)
+			}
+			merged := result.Snapshot.Tasks["a"]
+			if got := field(t, merged, "Title"); got != tt.wantTitle {
+				t.Errorf("Title = %v, want %v", got, tt.wantTitle)
+			}
+			if got := field(t, merged, keyUpdatedAt); got != tt.wantUpdate.Format(time.RFC3339) {
+				t.Errorf("UpdatedAt = %v, want %v", got, tt.wantUpdate.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestMergeSameChangeIsNoConflict(t *testing.T) {
+	base := record(t, baseTime, map[string]interface{}{"Title": "Title"})
+	ours := record(t, ourTime, map[string]interface{}{"Title": "New title"})
+	theirs := record(t, theirTime, map[string]interface{}{"Title": "New title"})
+
+	result := Merge(tasks(map[string]Record{"a": base}), tasks(map[string]Record{"a": ours}), tasks(map[string]Record{"a": theirs}))
+
+	if result.Conflicts != 0 {
+		t.Errorf("Conflicts = %d, want 0", result.Conflicts)
+	}
+}
+
+func TestMergeDeleteAndEdit(t *testing.T) {
+	base := record(t, baseTime, map[string]interface{}{"Title": "Title"})
+	edited := record(t, ourTime, map[string]interface{}{"Title": "Edited"})
+
+	tests := []struct {
+		name      string
+		ours      map[string]Record
+		theirs    map[string]Record
+		wantKept  bool
+		wantTitle string
+	}{
+		{"we edit, they delete", map[string]Record{"a": edited}, map[string]Record{}, true, "Edited"},
+		{"we delete, they edit", map[string]Record{}, map[string]Record{"a": edited}, true, "Edited"},
+		{"we delete, they keep", map[string]Record{}, map[string]Record{"a": base}, false, ""},
+		{"we keep, they delete", map[string]Record{"a": base}, map[string]Record{}, false, ""},
+		{"both delete", map[string]Record{}, map[string]Record{}, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Merge(tasks(map[string]Record{"a": base}), tasks(tt.ours), tasks(tt.theirs))
+
+			merged, kept := result.Snapshot.Tasks["a"]
+			if kept != tt.wantKept {
+				t.Fatalf("kept = %v, want %v", kept, tt.wantKept)
+			}
+			if kept {
+				if got := field(t, merged, "Title"); got != tt.wantTitle {
+					t.Errorf("Title = %v, want %v", got, tt.wantTitle)
+				}
+			}
+			if result.Conflicts != 0 {
+				t.Errorf("Conflicts = %d, want 0", result.Conflicts)
+			}
+		})
+	}
+}
+
+func TestMergeAddOnOneSide(t *testing.T) {
+	shared := record(t, baseTime, map[string]interface{}{"Title": "Shared"})
+	ourNew := record(t, ourTime, map[string]interface{}{"Title": "Ours"})
+	theirNew := record(t, theirTime, map[string]interface{}{"Title": "Theirs"})
+
+	result := Merge(
+		tasks(map[string]Record{"shared": shared}),
+		tasks(map[string]Record{"shared": shared, "ours": ourNew}),
+		tasks(map[string]Record{"shared": shared, "theirs": theirNew}),
+	)
+
+	if len(result.Snapshot.Tasks) != 3 {
+		t.Fatalf("merged %d tasks, want 3", len(result.Snapshot.Tasks))
+	}
+	if got := field(t, result.Snapshot.Tasks["ours"], "Title"); got != "Ours" {
+		t.Errorf("Title of our new task = %v", got)
+	}
+	if got := field(t, result.Snapshot.Tasks["theirs"], "Title"); got != "Theirs" {
+		t.Errorf("Title of their new task = %v", got)
+	}
+	if result.Conflicts != 0 {
+		t.Errorf("Conflicts = %d, want 0", result.Conflicts)
+	}
+}
+
+func TestMergeAddedFieldOnOneSide(t *testing.T) {
+	base := record(t, baseTime, map[string]interface{}{"Title": "Title"})
+	ours := record(t, ourTime, map[string]interface{}{"Title": "Title", "DueDate": 1700000000})
+	theirs := record(t, theirTime, map[string]interface{}{"Title": "Title"})
+
+	result := Merge(tasks(map[string]Record{"a": base}), tasks(map[string]Record{"a": ours}), tasks(map[string]Record{"a": theirs}))
+
+	if got := field(t, result.Snapshot.Tasks["a"], "DueDate"); got != float64(1700000000) {
+		t.Errorf("DueDate = %v, want our added value", got)
+	}
+	if result.Conflicts != 0 {
+		t.Errorf("Conflicts = %d, want 0", result.Conflicts)
+	}
+}
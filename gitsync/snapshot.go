@@ -0,0 +1,214 @@
+// Package gitsync keeps projects and tasks in a git repository, to sync them between machines.
+// Every record is a JSON file named by its UUID (projects/<uuid>.json, tasks/<uuid>.json), with sorted keys,
+// so that the same data always makes the same files. Diverged copies are merged field by field (see Merge).
+package gitsync
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+)
+
+const (
+	projectsDir = "projects"
+	tasksDir    = "tasks"
+	fileExt     = ".json"
+
+	// Keys of record fields. Local IDs differ between machines, so they are left out,
+	// and tasks refer to their project by UUID.
+	keyID          = "ID"
+	keyProjectID   = "ProjectID"
+	keyProjectUUID = "ProjectUUID"
+	keyUpdatedAt   = "UpdatedAt"
+	keyProjectKey  = "id" // ID of projects
+)
+
+// Record is a project or task as synced, by JSON keys of the model
+type Record map[string]json.RawMessage
+
+// Snapshot is the synced state, records by UUID
+type Snapshot struct {
+	Projects map[string]Record
+	Tasks    map[string]Record
+}
+
+// Files are the contents of snapshot files, by path in repository
+type Files map[string][]byte
+
+func newSnapshot() Snapshot {
+	return Snapshot{Projects: make(map[string]Record), Tasks: make(map[string]Record)}
+}
+
+// NewSnapshot makes a snapshot of projects and tasks, including the ones in Trash. Records must have a UUID.
+func NewSnapshot(projects []model.Project, tasks []model.Task) (Snapshot, error) {
+	snap := newSnapshot()
+	projectUUIDs := make(map[int64]string, len(projects))
+	for _, project := range projects {
+		if project.UUID == "" {
+			return snap, fmt.Errorf("project %q has no UUID", project.Title)
+		}
+
+		record, err := toRecord(project)
+		if err != nil {
+			return snap, err
+		}
+		delete(record, keyProjectKey)
+		snap.Projects[project.UUID] = record
+		projectUUIDs[project.ID] = project.UUID
+	}
+
+	for _, task := range tasks {
+		if task.UUID == "" {
+			return snap, fmt.Errorf("task %q has no UUID", task.Title)
+		}
+
+		// Due and start dates are days, while time of the day they are kept at differs by storage
+		task.SetDueDay(task.DueDay(task.Location()))
+		task.SetStartDay(task.StartDay(task.Location()))
+
+		record, err := toRecord(task)
+		if err != nil {
+			return snap, err
+		}
+		delete(record, keyID)
+		delete(record, keyProjectID)
+		record[keyProjectUUID], _ = json.Marshal(projectUUIDs[task.ProjectID])
+		snap.Tasks[task.UUID] = record
+	}
+
+	return snap, nil
+}
+
+// Project makes a project of the record. ID is left zero.
+func (r Record) Project() (model.Project, error) {
+	var project model.Project
+	err := r.decode(&project)
+	return project, err
+}
+
+// Task makes a task of the record, with the UUID of its project. ID and ProjectID are left zero.
+func (r Record) Task() (model.Task, string, error) {
+	var task model.Task
+	var projectUUID string
+	if err := r.decode(&task); err != nil {
+		return task, "", err
+	}
+	if raw, ok := r[keyProjectUUID]; ok {
+		if err := json.Unmarshal(raw, &projectUUID); err != nil {
+			return task, "", err
+		}
+	}
+
+	return task, projectUUID, nil
+}
+
+// Equivalent compares records, ignoring the time of last update
+func Equivalent(a, b Record) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		other, ok := b[key]
+		if !ok || (key != keyUpdatedAt && !sameJSON(value, other)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Files encodes the snapshot as files
+func (s Snapshot) Files() (Files, error) {
+	files := make(Files, len(s.Projects)+len(s.Tasks))
+	for dir, records := range map[string]map[string]Record{projectsDir: s.Projects, tasksDir: s.Tasks} {
+		for uuid, record := range records {
+			// Maps are encoded with sorted keys
+			content, err := json.MarshalIndent(record, "", "  ")
+			if err != nil {
+				return nil, err
+			}
+			files[path.Join(dir, uuid+fileExt)] = append(content, '\n')
+		}
+	}
+
+	return files, nil
+}
+
+// ReadSnapshot decodes snapshot files. Other files are ignored.
+func ReadSnapshot(files Files) (Snapshot, error) {
+	snap := newSnapshot()
+	for name, content := range files {
+		dir, file := path.Split(name)
+		if !strings.HasSuffix(file, fileExt) {
+			continue
+		}
+
+		var records map[string]Record
+		switch strings.TrimSuffix(dir, "/") {
+		case projectsDir:
+			records = snap.Projects
+		case tasksDir:
+			records = snap.Tasks
+		default:
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(content, &record); err != nil {
+			return snap, fmt.Errorf("%s: %w", name, err)
+		}
+		records[strings.TrimSuffix(file, fileExt)] = record
+	}
+
+	return snap, nil
+}
+
+func toRecord(value interface{}) (Record, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var record Record
+	if err := json.Unmarshal(encoded, &record); err != nil {
+		return nil, err
+	}
+
+	// Times are kept in UTC and whole seconds, so that machines in different time zones
+	// (and storages of different precision) write the same
+	for key, value := range record {
+		var text string
+		if json.Unmarshal(value, &text) != nil {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339Nano, text); err == nil {
+			record[key], _ = json.Marshal(t.UTC().Truncate(time.Second))
+		}
+	}
+
+	return record, nil
+}
+
+func (r Record) decode(value interface{}) error {
+	encoded, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(encoded, value)
+}
+
+// sameJSON compares JSON values, ignoring formatting
+func sameJSON(a, b json.RawMessage) bool {
+	var compactA, compactB bytes.Buffer
+	if json.Compact(&compactA, a) != nil || json.Compact(&compactB, b) != nil {
+		return bytes.Equal(a, b)
+	}
+
+	return bytes.Equal(compactA.Bytes(), compactB.Bytes())
+}
@@ -11,6 +11,8 @@ require (
 	github.com/rivo/tview v0.0.0-20210111184519-c818a0c789ee
 	github.com/spf13/pflag v1.0.5
 	github.com/subosito/gotenv v1.6.0
+	go.etcd.io/bbolt v1.3.5
+	gopkg.in/yaml.v2 v2.4.0
 )
 
 require (
@@ -26,12 +28,10 @@ require (
 	github.com/rivo/uniseg v0.2.0 // indirect
 	github.com/sergi/go-diff v1.1.0 // indirect
 	github.com/zyedidia/micro v1.4.1 // indirect
-	go.etcd.io/bbolt v1.3.5 // indirect
 	golang.org/x/net v0.0.0-20201224014010-6772e930b67b // indirect
 	golang.org/x/sys v0.5.0 // indirect
 	golang.org/x/text v0.12.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/protobuf v1.25.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
-	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
@@ -0,0 +1,199 @@
+// Package ical writes and reads tasks as iCalendar (RFC 5545), to use them with calendar applications
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/ticketmanager"
+)
+
+const (
+	productID       = "-//ajaxray//geek-life//EN"
+	dateLayout      = "20060102"
+	timestampLayout = "20060102T150405Z"
+	maxLineOctets   = 75
+	eventDuration   = 30 * time.Minute // Of events of tasks due at a time of the day
+)
+
+// Options controls which components are written for tasks
+type Options struct {
+	Events bool // Also write a VEVENT on due date (or time), for calendars that don't show tasks (VTODO)
+}
+
+// Write writes tasks with due date as VTODO (and VEVENT, if asked) components of a calendar.
+// Tasks without due date are skipped.
+func Write(w io.Writer, calendarName string, tasks []model.Task, projects []model.Project, opts Options) error {
+	projectTitles := make(map[int64]string, len(projects))
+	for _, project := range projects {
+		projectTitles[project.ID] = project.Title
+	}
+
+	out := &writer{w: bufio.NewWriter(w)}
+	out.line("BEGIN:VCALENDAR")
+	out.line("VERSION:2.0")
+	out.line("PRODID:" + productID)
+	out.line("CALSCALE:GREGORIAN")
+	out.line("X-WR-CALNAME:" + escapeText(calendarName))
+
+	now := time.Now()
+	for _, task := range tasks {
+		if task.DueDate == 0 {
+			continue
+		}
+
+		writeTodo(out, task, projectTitles[task.ProjectID], now)
+		if opts.Events {
+			writeEvent(out, task, projectTitles[task.ProjectID], now)
+		}
+	}
+
+	out.line("END:VCALENDAR")
+	if out.err != nil {
+		return out.err
+	}
+	return out.w.Flush()
+}
+
+// UID is the stable identifier of a task in calendars
+func UID(task model.Task) string {
+	if task.UUID != "" {
+		return task.UUID
+	}
+
+	// Tasks created before UUIDs were assigned
+	return fmt.Sprintf("task-%d@geek-life", task.ID)
+}
+
+func writeTodo(out *writer, task model.Task, project string, now time.Time) {
+	out.line("BEGIN:VTODO")
+	out.line("UID:" + UID(task))
+	out.line("DTSTAMP:" + stamp(task, now))
+	writeCommon(out, task, project)
+
+	// DTSTART and DUE are of the same value type, dates or timestamps
+	start := task.StartDay(time.Local)
+	if task.DueTime != "" {
+		if !start.IsZero() {
+			out.line("DTSTART:" + start.UTC().Format(timestampLayout))
+		}
+		out.line("DUE:" + task.DueAt(time.Local).UTC().Format(timestampLayout))
+	} else {
+		if !start.IsZero() {
+			out.line("DTSTART;VALUE=DATE:" + start.Format(dateLayout))
+		}
+		out.line("DUE;VALUE=DATE:" + task.DueDay(time.Local).Format(dateLayout))
+	}
+	if task.CurrentStatus() == model.StatusCancelled {
+		out.line("STATUS:CANCELLED")
+	} else if task.Completed {
+		out.line("STATUS:COMPLETED")
+		out.line("PERCENT-COMPLETE:100")
+		if task.CompletedAt != nil {
+			out.line("COMPLETED:" + task.CompletedAt.UTC().Format(timestampLayout))
+		}
+	} else {
+		out.line("STATUS:NEEDS-ACTION")
+	}
+	out.line("END:VTODO")
+}
+
+func writeEvent(out *writer, task model.Task, project string, now time.Time) {
+	out.line("BEGIN:VEVENT")
+	out.line("UID:event-" + UID(task))
+	out.line("DTSTAMP:" + stamp(task, now))
+	writeCommon(out, task, project)
+	if task.DueTime != "" {
+		due := task.DueAt(time.Local).UTC()
+		out.line("DTSTART:" + due.Format(timestampLayout))
+		out.line("DTEND:" + due.Add(eventDuration).Format(timestampLayout))
+	} else {
+		due := task.DueDay(time.Local)
+		out.line("DTSTART;VALUE=DATE:" + due.Format(dateLayout))
+		out.line("DTEND;VALUE=DATE:" + due.AddDate(0, 0, 1).Format(dateLayout))
+	}
+	out.line("TRANSP:TRANSPARENT")
+	if task.Completed {
+		// VEVENT has no completed status, it's marked like a task instead
+		out.line("X-GEEK-LIFE-STATUS:COMPLETED")
+	}
+	out.line("END:VEVENT")
+}
+
+func writeCommon(out *writer, task model.Task, project string) {
+	summary := task.Title
+	if task.IsLinked() {
+		summary = fmt.Sprintf("%s [%s]", task.Title, task.ExternalKey)
+	}
+	out.line("SUMMARY:" + escapeText(summary))
+
+	if task.Details != "" {
+		out.line("DESCRIPTION:" + escapeText(task.Details))
+	}
+	if project != "" {
+		out.line("CATEGORIES:" + escapeText(project))
+	}
+	if task.IsLinked() {
+		out.line("URL:" + ticketmanager.LinkURL(task.ExternalLink))
+	}
+	if !task.CreatedAt.IsZero() {
+		out.line("CREATED:" + task.CreatedAt.UTC().Format(timestampLayout))
+	}
+	if !task.UpdatedAt.IsZero() {
+		out.line("LAST-MODIFIED:" + task.UpdatedAt.UTC().Format(timestampLayout))
+	}
+}
+
+func stamp(task model.Task, now time.Time) string {
+	if !task.UpdatedAt.IsZero() {
+		return task.UpdatedAt.UTC().Format(timestampLayout)
+	}
+
+	return now.UTC().Format(timestampLayout)
+}
+
+// escapeText escapes a TEXT value (RFC 5545, 3.3.11)
+func escapeText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// writer writes content lines with CRLF endings, folded at 75 octets.
+// The first error is kept and later writes are skipped.
+type writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (out *writer) line(content string) {
+	if out.err != nil {
+		return
+	}
+
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		// Don't split multi-byte UTF-8 characters
+		for cut > 0 && content[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		if _, out.err = out.w.WriteString(content[:cut] + "\r\n "); out.err != nil {
+			return
+		}
+
+		content = content[cut:]
+		// Continuation lines start with a space, which counts in 75 octets
+		limit = maxLineOctets - 1
+	}
+
+	_, out.err = out.w.WriteString(content + "\r\n")
+}
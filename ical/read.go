@@ -0,0 +1,228 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const localTimestampLayout = "20060102T150405"
+
+// Calendar is the content of an iCalendar file, as much as geek-life uses
+type Calendar struct {
+	Name  string // X-WR-CALNAME, if set
+	Todos []Todo
+}
+
+// Todo is a VTODO component
+type Todo struct {
+	UID         string
+	Summary     string
+	Description string
+	Due         *time.Time
+	DueIsDate   bool       // DUE is a date, without time of the day
+	Start       *time.Time // DTSTART
+	Completed   bool       // STATUS is COMPLETED or CANCELLED
+	Cancelled   bool       // STATUS is CANCELLED
+	CompletedAt *time.Time
+	Categories  []string
+}
+
+// property is a content line, e.g. DUE;VALUE=DATE:20240131
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Read parses VTODO components of a calendar. Other components (VEVENT, VALARM etc.) are skipped.
+func Read(r io.Reader) (Calendar, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return Calendar{}, err
+	}
+
+	var calendar Calendar
+	var todo *Todo
+	depth := 0 // Nesting in VTODO, to skip properties of VALARM in it
+	for number, line := range lines {
+		prop, err := parseLine(line)
+		if err != nil {
+			return calendar, fmt.Errorf("line %d: %w", number+1, err)
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VTODO"):
+			todo, depth = &Todo{}, 1
+		case todo != nil && prop.name == "BEGIN":
+			depth++
+		case todo != nil && prop.name == "END":
+			if depth == 1 && !strings.EqualFold(prop.value, "VTODO") {
+				return calendar, fmt.Errorf("line %d: VTODO %s is not closed", number+1, todo.UID)
+			}
+			if depth--; depth == 0 {
+				if todo.UID == "" {
+					return calendar, fmt.Errorf("line %d: VTODO without UID", number+1)
+				}
+				calendar.Todos = append(calendar.Todos, *todo)
+				todo = nil
+			}
+		case todo != nil && depth == 1:
+			if err := todo.set(prop); err != nil {
+				return calendar, fmt.Errorf("line %d: %w", number+1, err)
+			}
+		case todo == nil && prop.name == "X-WR-CALNAME":
+			calendar.Name = unescapeText(prop.value)
+		}
+	}
+
+	if todo != nil {
+		return calendar, fmt.Errorf("VTODO %s is not closed", todo.UID)
+	}
+	return calendar, nil
+}
+
+func (todo *Todo) set(prop property) error {
+	switch prop.name {
+	case "UID":
+		todo.UID = prop.value
+	case "SUMMARY":
+		todo.Summary = unescapeText(prop.value)
+	case "DESCRIPTION":
+		todo.Description = unescapeText(prop.value)
+	case "STATUS":
+		status := strings.ToUpper(prop.value)
+		todo.Completed = status == "COMPLETED" || status == "CANCELLED"
+		todo.Cancelled = status == "CANCELLED"
+	case "CATEGORIES":
+		for _, category := range splitList(prop.value) {
+			if category = strings.TrimSpace(unescapeText(category)); category != "" {
+				todo.Categories = append(todo.Categories, category)
+			}
+		}
+	case "DUE":
+		due, err := parseTime(prop)
+		if err != nil {
+			return fmt.Errorf("invalid DUE: %w", err)
+		}
+		todo.Due = &due
+		todo.DueIsDate = isDate(prop)
+	case "DTSTART":
+		start, err := parseTime(prop)
+		if err != nil {
+			return fmt.Errorf("invalid DTSTART: %w", err)
+		}
+		todo.Start = &start
+	case "COMPLETED":
+		completedAt, err := parseTime(prop)
+		if err != nil {
+			return fmt.Errorf("invalid COMPLETED: %w", err)
+		}
+		todo.CompletedAt = &completedAt
+	}
+
+	return nil
+}
+
+// unfold joins folded content lines (RFC 5545, 3.1)
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case line == "":
+		case (line[0] == ' ' || line[0] == '\t') && len(lines) > 0:
+			lines[len(lines)-1] += line[1:]
+		default:
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, scanner.Err()
+}
+
+// parseLine splits a content line into name, parameters and value. Quoted parameter values may contain ; and :
+func parseLine(line string) (property, error) {
+	prop := property{params: map[string]string{}}
+
+	inQuote := false
+	start := 0
+	var parts []string
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '"':
+			inQuote = !inQuote
+		case !inQuote && line[i] == ';':
+			parts = append(parts, line[start:i])
+			start = i + 1
+		case !inQuote && line[i] == ':':
+			parts = append(parts, line[start:i])
+			prop.value = line[i+1:]
+			prop.name = strings.ToUpper(parts[0])
+			for _, param := range parts[1:] {
+				if key, value, ok := strings.Cut(param, "="); ok {
+					prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+				}
+			}
+			return prop, nil
+		}
+	}
+
+	return prop, fmt.Errorf("invalid content line %q", line)
+}
+
+// parseTime reads DATE and DATE-TIME values. Dates and floating times are in local time zone.
+func parseTime(prop property) (time.Time, error) {
+	location := time.Local
+	if tzid, ok := prop.params["TZID"]; ok {
+		if loaded, err := time.LoadLocation(tzid); err == nil {
+			location = loaded
+		}
+	}
+
+	switch {
+	case isDate(prop):
+		return time.ParseInLocation(dateLayout, prop.value, time.Local)
+	case strings.HasSuffix(prop.value, "Z"):
+		return time.Parse(timestampLayout, prop.value)
+	default:
+		return time.ParseInLocation(localTimestampLayout, prop.value, location)
+	}
+}
+
+// isDate checks if a time property is a date, e.g. DUE;VALUE=DATE:20240131
+func isDate(prop property) bool {
+	return prop.params["VALUE"] == "DATE" || len(prop.value) == len(dateLayout)
+}
+
+// splitList splits a comma separated value, leaving escaped commas
+func splitList(value string) []string {
+	var items []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			items = append(items, value[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(items, value[start:])
+}
+
+// unescapeText reverses escapeText
+func unescapeText(text string) string {
+	return strings.NewReplacer(
+		`\\`, `\`,
+		`\;`, ";",
+		`\,`, ",",
+		`\n`, "\n",
+		`\N`, "\n",
+	).Replace(text)
+}
@@ -0,0 +1,125 @@
+package ical
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func calendar(lines ...string) string {
+	return strings.Join(append(append([]string{"BEGIN:VCALENDAR", "VERSION:2.0"}, lines...), "END:VCALENDAR"), "\r\n")
+}
+
+func todo(lines ...string) string {
+	return strings.Join(append(append([]string{"BEGIN:VTODO", "UID:t1"}, lines...), "END:VTODO"), "\r\n")
+}
+
+func TestRead(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	at := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name string
+		ics  string
+		want Todo
+	}{
+		{"folded lines", calendar(todo("SUMMARY:Write the quarterly", "  report", "DESCRIPTION:First", "\tsecond")),
+			Todo{UID: "t1", Summary: "Write the quarterly report", Description: "Firstsecond"}},
+		{"escaped text", calendar(todo(`SUMMARY:Milk\, eggs\; bread`, `DESCRIPTION:Aisle 3\nShelf \\2\N`)),
+			Todo{UID: "t1", Summary: "Milk, eggs; bread", Description: "Aisle 3\nShelf \\2\n"}},
+		{"categories", calendar(todo(`CATEGORIES:home,work\,office, ,urgent`)),
+			Todo{UID: "t1", Categories: []string{"home", "work,office", "urgent"}}},
+		{"due date", calendar(todo("DUE;VALUE=DATE:20240131")),
+			Todo{UID: "t1", Due: at(time.Date(2024, time.January, 31, 0, 0, 0, 0, time.Local)), DueIsDate: true}},
+		{"due date without VALUE", calendar(todo("DUE:20240131")),
+			Todo{UID: "t1", Due: at(time.Date(2024, time.January, 31, 0, 0, 0, 0, time.Local)), DueIsDate: true}},
+		{"due with TZID", calendar(todo(`DUE;TZID="America/New_York":20240131T183000`)),
+			Todo{UID: "t1", Due: at(time.Date(2024, time.January, 31, 18, 30, 0, 0, newYork))}},
+		{"due in UTC", calendar(todo("DUE:20240131T183000Z", "DTSTART;VALUE=DATE:20240129")),
+			Todo{UID: "t1", Due: at(time.Date(2024, time.January, 31, 18, 30, 0, 0, time.UTC)),
+				Start: at(time.Date(2024, time.January, 29, 0, 0, 0, 0, time.Local))}},
+		{"floating due", calendar(todo("DUE:20240131T183000")),
+			Todo{UID: "t1", Due: at(time.Date(2024, time.January, 31, 18, 30, 0, 0, time.Local))}},
+		{"completed", calendar(todo("STATUS:COMPLETED", "COMPLETED:20240201T090000Z")),
+			Todo{UID: "t1", Completed: true, CompletedAt: at(time.Date(2024, time.February, 1, 9, 0, 0, 0, time.UTC))}},
+		{"cancelled", calendar(todo("status:cancelled")),
+			Todo{UID: "t1", Completed: true, Cancelled: true}},
+		{"alarm", calendar(todo("BEGIN:VALARM", "DESCRIPTION:Reminder", "END:VALARM", "SUMMARY:Call")),
+			Todo{UID: "t1", Summary: "Call"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal, err := Read(strings.NewReader(tt.ics))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(cal.Todos) != 1 {
+				t.Fatalf("got %d todos, want 1", len(cal.Todos))
+			}
+
+			got := cal.Todos[0]
+			if !sameTime(got.Due, tt.want.Due) || !sameTime(got.Start, tt.want.Start) || !sameTime(got.CompletedAt, tt.want.CompletedAt) {
+				t.Errorf("Due, Start, CompletedAt = %v, %v, %v, want %v, %v, %v",
+					got.Due, got.Start, got.CompletedAt, tt.want.Due, tt.want.Start, tt.want.CompletedAt)
+			}
+			got.Due, got.Start, got.CompletedAt = tt.want.Due, tt.want.Start, tt.want.CompletedAt
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadName(t *testing.T) {
+	cal, err := Read(strings.NewReader(calendar(`X-WR-CALNAME:Home\, garden`, "BEGIN:VEVENT", "UID:e1", "END:VEVENT")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cal.Name != "Home, garden" || len(cal.Todos) != 0 {
+		t.Errorf("Read() = %+v, want only name", cal)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		ics  string
+	}{
+		{"line without colon", calendar(todo("SUMMARY"))},
+		{"without UID", calendar("BEGIN:VTODO", "SUMMARY:Lost", "END:VTODO")},
+		{"not closed", calendar("BEGIN:VTODO", "UID:t1")},
+		{"invalid due", calendar(todo("DUE:tomorrow"))},
+		{"invalid due date", calendar(todo("DUE;VALUE=DATE:2024-01-31"))},
+		{"invalid start", calendar(todo("DTSTART:20241331T000000Z"))},
+		{"invalid completed", calendar(todo("COMPLETED:yesterday"))},
+		{"not a calendar", "Hello, world"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Read(strings.NewReader(tt.ics)); err == nil {
+				t.Error("Read() gave no error")
+			}
+		})
+	}
+}
+
+func TestReadEmpty(t *testing.T) {
+	for _, ics := range []string{"", "\r\n", " folded\r\n"} {
+		if cal, err := Read(strings.NewReader(ics)); err == nil && len(cal.Todos) != 0 {
+			t.Errorf("Read(%q) = %+v", ics, cal)
+		}
+	}
+}
+
+func sameTime(got, want *time.Time) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return got.Equal(*want)
+}
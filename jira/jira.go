@@ -15,6 +15,8 @@ type Jira interface {
 	UpdateEpic(title, description string, epicID string) (string, error)
 	CreateTask(title, description string, epicID string) (string, error)
 	UpdateTask(title, description string, completed bool, taskID string) error
+	MoveTask(taskID, epicID string) error
+	TransitionTask(taskID string, statusNames []string, category string) (string, error)
 	ListEpics() ([]JiraIssue, error)
 	ListGeekLifeEpics() ([]JiraIssue, error)
 	ListTasksForEpic(epicID string) ([]JiraIssue, error)
@@ -140,36 +142,32 @@ func (j *jira) CreateEpic(title, description string) (string, error) {
 }
 
 func (j *jira) UpdateEpic(title, description string, epicID string) (string, error) {
-	// Construct the request payload
-	payload := map[string]interface{}{
-		"fields": map[string]interface{}{
-			"project": map[string]string{
-				"key": j.projectKey,
-			},
-			"summary":     title,
-			"description": description,
-			"issuetype": map[string]string{
-				"name": "Epic",
-			},
-			j.config["epicName"]: title,
-		},
+	// Ensure config is loaded to keep the epic name field in sync with the title
+	if err := j.ensureConfigLoaded(); err != nil {
+		util.LogWarning("failed to load config: %v", err)
 	}
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		return "", err
+
+	fields := map[string]interface{}{
+		"summary":     title,
+		"description": description,
 	}
-	url := fmt.Sprintf("/rest/api/2/issue/%s", epicID)
-	b, err := j.client.MakeRequest("PUT", url, payloadBytes)
+	if epicNameField, exists := j.config["epicName"]; exists && epicNameField != "" {
+		fields[epicNameField] = title
+	}
+
+	payloadBytes, err := json.Marshal(map[string]interface{}{"fields": fields})
 	if err != nil {
 		return "", err
 	}
-	epic := &JiraIssue{}
-	err = json.Unmarshal(b, epic)
-	if err != nil {
-		util.LogError("error unmarshalling epic response: %+v", err)
+	util.LogDebug("Epic update payload: %s", payloadBytes)
+
+	// Successful update responds with no content
+	url := fmt.Sprintf("/rest/api/2/issue/%s", epicID)
+	if _, err := j.client.MakeRequest("PUT", url, payloadBytes); err != nil {
 		return "", err
 	}
-	return epic.Key, nil
+
+	return epicID, nil
 }
 
 func (j *jira) CreateTask(title, description string, epicID string) (string, error) {
@@ -211,12 +209,41 @@ func (j *jira) CreateTask(title, description string, epicID string) (string, err
 	return task.Key, nil
 }
 
+// Status categories of Jira workflow
+const (
+	CategoryNew        = "new"
+	CategoryInProgress = "indeterminate"
+	CategoryDone       = "done"
+)
+
+// categoryStatusNames are common status names of every category, to match when category is not reported
+var categoryStatusNames = map[string][]string{
+	CategoryNew:        {"To Do", "Open", "New"},
+	CategoryInProgress: {"In Progress", "Doing", "Started"},
+	CategoryDone:       {"Done", "Closed", "Complete", "Resolved"},
+}
+
 func (j *jira) getTransitionID(taskID string, completed bool) (string, error) {
+	category := CategoryNew
+	if completed {
+		category = CategoryDone
+	}
+
+	transitionID, _, err := j.findTransitionID(taskID, nil, category)
+	if err != nil {
+		return "", fmt.Errorf("no suitable transition found for completed=%v", completed)
+	}
+	return transitionID, nil
+}
+
+// findTransitionID finds the transition of a task to one of the named statuses (in order of preference),
+// or else to a status of the category (see CategoryNew etc.). The name of the target status is returned along.
+func (j *jira) findTransitionID(taskID string, statusNames []string, category string) (string, string, error) {
 	// Get available transitions for this task
 	url := fmt.Sprintf("/rest/api/2/issue/%s/transitions", taskID)
 	b, err := j.client.MakeRequest("GET", url, nil)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	var transitions struct {
@@ -234,7 +261,7 @@ func (j *jira) getTransitionID(taskID string, completed bool) (string, error) {
 
 	err = json.Unmarshal(b, &transitions)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	util.LogDebug("Available transitions for task %s:", taskID)
@@ -243,39 +270,60 @@ func (j *jira) getTransitionID(taskID string, completed bool) (string, error) {
 			transition.ID, transition.Name, transition.To.Name, transition.To.StatusCategory.Key)
 	}
 
-	// Look for appropriate transition based on completion status
-	targetCategory := "new"
-	if completed {
-		targetCategory = "done"
+	for _, status := range statusNames {
+		for _, transition := range transitions.Transitions {
+			if strings.EqualFold(transition.To.Name, status) {
+				util.LogInfo("Selected transition ID %s for status %q", transition.ID, status)
+				return transition.ID, transition.To.Name, nil
+			}
+		}
 	}
 
 	for _, transition := range transitions.Transitions {
-		if transition.To.StatusCategory.Key == targetCategory {
-			util.LogInfo("Selected transition ID %s for completed=%v", transition.ID, completed)
-			return transition.ID, nil
+		if category != "" && transition.To.StatusCategory.Key == category {
+			util.LogInfo("Selected transition ID %s for category %s", transition.ID, category)
+			return transition.ID, transition.To.Name, nil
 		}
 	}
 
 	// Fallback to common transition names
-	targetNames := []string{"To Do", "Open", "New"}
-	if completed {
-		targetNames = []string{"Done", "Closed", "Complete", "Resolved"}
-	}
-
-	for _, targetName := range targetNames {
+	for _, targetName := range categoryStatusNames[category] {
 		for _, transition := range transitions.Transitions {
 			if strings.Contains(strings.ToLower(transition.To.Name), strings.ToLower(targetName)) {
-				util.LogInfo(
-					"Selected transition ID %s by name match for completed=%v",
-					transition.ID,
-					completed,
-				)
-				return transition.ID, nil
+				util.LogInfo("Selected transition ID %s by name match for category %s", transition.ID, category)
+				return transition.ID, transition.To.Name, nil
 			}
 		}
 	}
 
-	return "", fmt.Errorf("no suitable transition found for completed=%v", completed)
+	if len(statusNames) > 0 {
+		return "", "", fmt.Errorf("no transition to status %q found", statusNames[0])
+	}
+	return "", "", fmt.Errorf("no transition to category %q found", category)
+}
+
+// TransitionTask moves a task to one of the named statuses, or else to a status of the category (see CategoryNew etc.).
+// Empty category moves by name only. The name of the status the task is moved to is returned.
+func (j *jira) TransitionTask(taskID string, statusNames []string, category string) (string, error) {
+	transitionID, statusName, err := j.findTransitionID(taskID, statusNames, category)
+	if err != nil {
+		return "", err
+	}
+
+	payloadBytes, err := json.Marshal(map[string]interface{}{
+		"transition": map[string]interface{}{
+			"id": transitionID,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("/rest/api/2/issue/%s/transitions", taskID)
+	if _, err = j.client.MakeRequest("POST", url, payloadBytes); err != nil {
+		return "", err
+	}
+	return statusName, nil
 }
 
 func (j *jira) UpdateTask(
@@ -333,6 +381,27 @@ func (j *jira) UpdateTask(
 	return nil
 }
 
+// MoveTask sets the parent epic of a task
+func (j *jira) MoveTask(taskID, epicID string) error {
+	payload := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"parent": map[string]string{
+				"key": epicID,
+			},
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	util.LogDebug("Task move payload: %s", payloadBytes)
+
+	// Successful update responds with no content
+	url := fmt.Sprintf("/rest/api/2/issue/%s", taskID)
+	_, err = j.client.MakeRequest("PUT", url, payloadBytes)
+	return err
+}
+
 func (j *jira) ListEpics() ([]JiraIssue, error) {
 	jql := fmt.Sprintf("project=%s AND issuetype=Epic", j.projectKey)
 	encodedJQL := url.QueryEscape(jql)
@@ -0,0 +1,129 @@
+// Package migration applies versioned schema changes to the storm database.
+//
+// Each Migration is registered in code with a unique version. The highest applied version
+// is stored in the database, so only pending migrations run on next start.
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/asdine/storm/v3"
+)
+
+// Storm KV buckets and keys used to track applied migrations
+const (
+	schemaBucket  = "schema"
+	versionKey    = "version"
+	appliedBucket = "schema_migrations"
+)
+
+// Migration is a single schema change step.
+// Up must be idempotent, it may run again if the process stops before the version is saved.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(db *storm.DB) error
+}
+
+// Status describes a registered migration and whether it is applied
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+var registered []Migration
+
+// Register adds a migration to the ordered list. Panics on duplicate versions.
+func Register(m Migration) {
+	for _, existing := range registered {
+		if existing.Version == m.Version {
+			panic(fmt.Sprintf("migration version %d is registered twice (%s, %s)", m.Version, existing.Name, m.Name))
+		}
+	}
+
+	registered = append(registered, m)
+	sort.Slice(registered, func(i, j int) bool { return registered[i].Version < registered[j].Version })
+}
+
+// All returns registered migrations ordered by version
+func All() []Migration {
+	return append([]Migration(nil), registered...)
+}
+
+// CurrentVersion returns the version of last applied migration, 0 for a new database
+func CurrentVersion(db *storm.DB) (int, error) {
+	var version int
+	err := db.Get(schemaBucket, versionKey, &version)
+	if err == storm.ErrNotFound {
+		return 0, nil
+	}
+
+	return version, err
+}
+
+// Pending lists migrations that are not applied yet
+func Pending(db *storm.DB) ([]Migration, error) {
+	current, err := CurrentVersion(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, m := range registered {
+		if m.Version > current {
+			pending = append(pending, m)
+		}
+	}
+
+	return pending, nil
+}
+
+// Run applies all pending migrations in order and returns the applied ones.
+// It stops at the first failure, leaving the schema version at the last successful step.
+func Run(db *storm.DB) ([]Migration, error) {
+	pending, err := Pending(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, m := range pending {
+		if err := m.Up(db); err != nil {
+			return applied, fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+
+		if err := db.Set(appliedBucket, m.Version, time.Now()); err != nil {
+			return applied, err
+		}
+		if err := db.Set(schemaBucket, versionKey, m.Version); err != nil {
+			return applied, err
+		}
+
+		applied = append(applied, m)
+	}
+
+	return applied, nil
+}
+
+// Statuses reports every registered migration with its applied state
+func Statuses(db *storm.DB) ([]Status, error) {
+	current, err := CurrentVersion(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(registered))
+	for i, m := range registered {
+		statuses[i] = Status{Migration: m, Applied: m.Version <= current}
+
+		var appliedAt time.Time
+		if err := db.Get(appliedBucket, m.Version, &appliedAt); err == nil {
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+
+	return statuses, nil
+}
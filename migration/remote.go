@@ -0,0 +1,153 @@
+package migration
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/asdine/storm/v3"
+
+	"github.com/ajaxray/geek-life/jira"
+	"github.com/ajaxray/geek-life/model"
+	repo "github.com/ajaxray/geek-life/repository/storm"
+	"github.com/ajaxray/geek-life/ticketmanager"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// RemoteResult counts the projects a remote refresh step fixed, and the ones it could not
+type RemoteResult struct {
+	Name   string
+	Fixed  int
+	Failed int
+}
+
+// remoteSteps fix data of linked projects from their tickets. Unlike migrations they need the ticket providers,
+// so they are run on demand (`geek-life migrate --refresh-remote`) and can be run again for the ones failed.
+var remoteSteps = []struct {
+	name string
+	run  func(db *storm.DB) (fixed, failed int, err error)
+}{
+	{"fix numeric JIRA epic keys", fixJiraEpicKeys},
+	{"backfill remote creation dates", backfillRemoteDates},
+}
+
+// RefreshRemote runs every remote refresh step. Returns an error if any project could not be fixed,
+// besides the results of the steps run.
+func RefreshRemote(db *storm.DB) ([]RemoteResult, error) {
+	var results []RemoteResult
+	failed := 0
+	for _, step := range remoteSteps {
+		fixed, stepFailed, err := step.run(db)
+		if err != nil {
+			return results, fmt.Errorf("%s failed: %w", step.name, err)
+		}
+
+		results = append(results, RemoteResult{Name: step.name, Fixed: fixed, Failed: stepFailed})
+		failed += stepFailed
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("%d projects could not be fixed, see the log and run again", failed)
+	}
+	return results, nil
+}
+
+// fixJiraEpicKeys replaces internal numeric JIRA IDs saved as epic key (e.g. 10023) with issue keys (PROJ-123).
+// Projects that could not be fixed are logged and kept unchanged.
+func fixJiraEpicKeys(db *storm.DB) (fixed, failed int, err error) {
+	projectRepo := repo.NewProjectRepository(db)
+	projects, err := projectRepo.GetAll()
+	if err != nil && err != storm.ErrNotFound {
+		return 0, 0, err
+	}
+
+	numericRegex := regexp.MustCompile(`^\d+$`)
+	var candidates []model.Project
+	for _, project := range projects {
+		if project.Provider == string(ticketmanager.ProviderJira) && numericRegex.MatchString(project.ExternalKey) {
+			candidates = append(candidates, project)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return 0, 0, nil
+	}
+
+	jiraConfig := util.GetJiraConfig()
+	if !jiraConfig.IsConfigured() {
+		util.LogWarning("Skipped fixing %d numeric JIRA keys: JIRA is not configured", len(candidates))
+		return 0, len(candidates), nil
+	}
+
+	jiraClient := jira.NewJiraClient(
+		jiraConfig.URL,
+		jiraConfig.Username,
+		jiraConfig.APIToken,
+		jiraConfig.APIToken,
+		jiraConfig.ProjectKey,
+	)
+
+	for _, project := range candidates {
+		epic, err := jiraClient.DescribeEpic(project.ExternalKey)
+		if err != nil || epic.Key == "" {
+			util.LogWarning("Could not fetch epic details of project '%s' (ID %s): %v", project.Title, project.ExternalKey, err)
+			failed++
+			continue
+		}
+
+		project.ExternalID = epic.ID
+		project.ExternalKey = epic.Key
+		project.ExternalURL = ticketmanager.ProviderJira.TicketURL(epic.Key)
+		if err := projectRepo.Update(&project); err != nil {
+			return fixed, failed, err
+		}
+		util.LogInfo("Updated JIRA key of project '%s' from %s to %s", project.Title, epic.ID, epic.Key)
+		fixed++
+	}
+
+	return fixed, failed, nil
+}
+
+// backfillRemoteDates fetches creation dates of linked epics, used for sorting projects
+func backfillRemoteDates(db *storm.DB) (fixed, failed int, err error) {
+	projectRepo := repo.NewProjectRepository(db)
+	projects, err := projectRepo.GetAll()
+	if err != nil && err != storm.ErrNotFound {
+		return 0, 0, err
+	}
+
+	tickets := ticketmanager.NewRegistry()
+	for _, project := range projects {
+		if !project.IsLinked() || project.RemoteCreatedAt != nil {
+			continue
+		}
+
+		ticketManager, err := tickets.Get(project.Provider)
+		if err != nil {
+			util.LogWarning("Skipped creation date of project '%s': %v", project.Title, err)
+			failed++
+			continue
+		}
+
+		epicDetails, err := ticketManager.DescribeEpic(project.ExternalKey)
+		if err != nil {
+			util.LogWarning("Failed to fetch epic details of project '%s': %v", project.Title, err)
+			failed++
+			continue
+		}
+
+		createdDate, err := ticketmanager.ParseDate(epicDetails.CreatedDate)
+		if err != nil {
+			util.LogWarning("Failed to parse creation date '%s': %v", epicDetails.CreatedDate, err)
+			failed++
+			continue
+		}
+
+		project.RemoteCreatedAt = &createdDate
+		if err := projectRepo.Update(&project); err != nil {
+			return fixed, failed, err
+		}
+		fixed++
+	}
+
+	return fixed, failed, nil
+}
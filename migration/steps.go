@@ -0,0 +1,126 @@
+package migration
+
+import (
+	"fmt"
+
+	"github.com/asdine/storm/v3"
+
+	"github.com/ajaxray/geek-life/model"
+	repo "github.com/ajaxray/geek-life/repository/storm"
+	"github.com/ajaxray/geek-life/ticketmanager"
+	"github.com/ajaxray/geek-life/util"
+)
+
+func init() {
+	Register(Migration{Version: 1, Name: "provider neutral ticket links", Up: migrateExternalLinks})
+	Register(Migration{Version: 2, Name: "task timestamps from linked tickets", Up: backfillTaskTimestamps})
+	Register(Migration{Version: 3, Name: "UUIDs of projects and tasks", Up: backfillUUIDs})
+	Register(Migration{Version: 4, Name: "time zones and start dates of tasks", Up: anchorTaskDates})
+}
+
+// reindex rebuilds indexes of a model. It also drops indexes of removed fields.
+func reindex(db *storm.DB, data interface{}) error {
+	// An empty database has nothing to index
+	if err := db.ReIndex(data); err != nil && err != storm.ErrNotFound {
+		return err
+	}
+
+	return nil
+}
+
+// migrateExternalLinks converts JIRA specific fields to model.ExternalLink
+func migrateExternalLinks(db *storm.DB) error {
+	converted, err := repo.MigrateExternalLinks(db, string(ticketmanager.GetProviderType()))
+	if err != nil {
+		return err
+	}
+	util.LogInfo("Converted ticket links of %d projects/tasks", converted)
+
+	if err := reindex(db, &model.Project{}); err != nil {
+		return err
+	}
+	return reindex(db, &model.Task{})
+}
+
+// backfillTaskTimestamps sets creation and completion times of existing tasks from their linked tickets.
+// Times of unlinked tasks are unknown and left empty. Saved directly, to keep it out of task history.
+func backfillTaskTimestamps(db *storm.DB) error {
+	var tasks []model.Task
+	if err := db.All(&tasks); err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		changed := false
+		if task.CreatedAt.IsZero() && task.RemoteCreatedAt != nil {
+			task.CreatedAt = *task.RemoteCreatedAt
+			changed = true
+		}
+		if task.Completed && task.CompletedAt == nil && task.RemoteUpdatedAt != nil {
+			task.CompletedAt = task.RemoteUpdatedAt
+			changed = true
+		}
+
+		if changed {
+			if err := db.Save(&task); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// backfillUUIDs assigns UUIDs to projects and tasks created before they were assigned on creation.
+// Tasks get the UUID made of the identifier they were exported with (task-<ID>@geek-life in calendars,
+// its name based UUID in Taskwarrior), so that importing earlier exports updates them.
+func backfillUUIDs(db *storm.DB) error {
+	var projects []model.Project
+	if err := db.All(&projects); err != nil {
+		return err
+	}
+	for _, project := range projects {
+		if project.UUID == "" {
+			project.UUID = util.NewUUID()
+			if err := db.Save(&project); err != nil {
+				return err
+			}
+		}
+	}
+
+	var tasks []model.Task
+	if err := db.All(&tasks); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if task.UUID == "" {
+			task.UUID = util.NameUUID(fmt.Sprintf("task-%d@geek-life", task.ID))
+			if err := db.Save(&task); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// anchorTaskDates sets the local time zone to tasks with due date, which are stored as midnight of it,
+// so that their dates stay the same when local zone changes. Then indexes the new start date of tasks.
+func anchorTaskDates(db *storm.DB) error {
+	zone := util.LocalZoneName()
+
+	var tasks []model.Task
+	if err := db.All(&tasks); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if zone != "" && task.DueDate != 0 && task.TimeZone == "" {
+			task.TimeZone = zone
+			if err := db.Save(&task); err != nil {
+				return err
+			}
+		}
+	}
+
+	return reindex(db, &model.Task{})
+}
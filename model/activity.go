@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// Sources of the changes recorded in Activity
+const (
+	ActivitySourceTUI    = "tui"
+	ActivitySourceCLI    = "cli"
+	ActivitySourceSync   = "sync"
+	ActivitySourceImport = "import"
+)
+
+// Actions recorded in Activity
+const (
+	ActivityCreated  = "created"
+	ActivityUpdated  = "updated"
+	ActivityDeleted  = "deleted"
+	ActivityRestored = "restored"
+	ActivityPurged   = "purged"
+)
+
+// Activity is an entry of the (append only) change history of a Task, or of a Project when TaskID is 0
+type Activity struct {
+	ID        int64     `storm:"id,increment" json:"id"`
+	TaskID    int64     `storm:"index"        json:"task_id"`
+	ProjectID int64     `storm:"index"        json:"project_id,omitempty"`
+	Action    string    `storm:"index"        json:"action"`
+	Field     string    `                     json:"field,omitempty"`
+	OldValue  string    `                     json:"old_value,omitempty"`
+	NewValue  string    `                     json:"new_value,omitempty"`
+	Source    string    `storm:"index"        json:"source"`
+	CreatedAt time.Time `storm:"index"        json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// ExternalLink connects a Project or Task with its counterpart in a ticket system (JIRA, Linear etc.)
+type ExternalLink struct {
+	Provider        string     `storm:"index" json:"provider,omitempty"`
+	ExternalID      string     `              json:"external_id,omitempty"`
+	ExternalKey     string     `storm:"index" json:"external_key,omitempty"`
+	ExternalURL     string     `              json:"external_url,omitempty"`
+	RemoteCreatedAt *time.Time `storm:"index" json:"remote_created_at,omitempty"`
+	RemoteUpdatedAt *time.Time `              json:"remote_updated_at,omitempty"`
+}
+
+// IsLinked checks if the record is connected with a ticket
+func (l ExternalLink) IsLinked() bool {
+	return l.ExternalKey != ""
+}
@@ -4,17 +4,23 @@ import "time"
 
 // Project represent a collection of related tasks (tags of Habitica)
 type Project struct {
-	ID              int64      `storm:"id,increment" json:"id"`
-	Title           string     `storm:"index"        json:"title"`
-	UUID            string     `storm:"unique"       json:"uuid,omitempty"`
-	Jira            string     `storm:"index"        json:"jira"`
-	JiraCreatedDate *time.Time `storm:"index"        json:"jira_created_date,omitempty"`
+	ID           int64      `storm:"id,increment" json:"id"`
+	Title        string     `storm:"index"        json:"title"`
+	Description  string     `                     json:"description,omitempty"` // Markdown
+	UUID         string     `storm:"unique"       json:"uuid,omitempty"`
+	DeletedAt    *time.Time `                     json:"deleted_at,omitempty"`
+	ExternalLink `storm:"inline"`
 }
 
 func (p Project) GetTitle() string {
-	if p.Jira != "" {
+	if p.IsLinked() {
 		return p.Title + " [Ticket]"
 	}
 
 	return p.Title + " [No Ticket]"
 }
+
+// IsDeleted checks if the project is in Trash
+func (p Project) IsDeleted() bool {
+	return p.DeletedAt != nil
+}
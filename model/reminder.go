@@ -0,0 +1,123 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reminder is when to remind of a task: at a moment, or a while before the task is due
+type Reminder struct {
+	At     int64         `json:"At,omitempty"`     // Unix time to remind at
+	Before time.Duration `json:"Before,omitempty"` // How long before due time to remind, when At is not set
+}
+
+// Time is the moment to remind of the task. Reminders before tasks due any time of a day count from dayStart
+// of the due day in loc, e.g. 1h before a task due on Friday is Friday 08:00 for dayStart of 9 hours.
+// Zero for reminders before unscheduled tasks.
+func (r Reminder) Time(task Task, dayStart time.Duration, loc *time.Location) time.Time {
+	if r.At != 0 {
+		return time.Unix(r.At, 0)
+	}
+	if task.DueDate == 0 {
+		return time.Time{}
+	}
+
+	due := task.DueAt(loc)
+	if task.DueTime == "" {
+		year, month, day := task.DueDay(loc).Date()
+		due = time.Date(year, month, day, 0, 0, int(dayStart/time.Second), 0, loc)
+	}
+	return due.Add(-r.Before)
+}
+
+// String is the reminder as it's written in files, e.g. "15m before", "at due" or "2024-12-03T09:00:00+06:00"
+func (r Reminder) String() string {
+	switch {
+	case r.At != 0:
+		return time.Unix(r.At, 0).Format(time.RFC3339)
+	case r.Before == 0:
+		return reminderAtDue
+	}
+
+	return FormatDuration(r.Before) + " before"
+}
+
+// reminderAtDue is a reminder at due time, i.e. zero Reminder
+const reminderAtDue = "at due"
+
+// ParseReminder parses a reminder as written by String
+func ParseReminder(text string) (Reminder, error) {
+	text = strings.TrimSpace(text)
+	if text == reminderAtDue {
+		return Reminder{}, nil
+	}
+	if before, err := ParseBefore(text); err == nil {
+		return Reminder{Before: before}, nil
+	}
+
+	at, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return Reminder{}, fmt.Errorf("invalid reminder %q", text)
+	}
+	return Reminder{At: at.Unix()}, nil
+}
+
+// durationUnits are the units of reminder durations, largest first
+var durationUnits = []struct {
+	symbol string
+	size   time.Duration
+}{
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+}
+
+// durationPart is a number and unit of a duration, e.g. 15m, 2 hours or 1 day
+var durationPart = regexp.MustCompile(`^(\d+)\s*(weeks?|w|days?|d|hours?|hrs?|h|minutes?|mins?|m)\s*`)
+
+// FormatDuration writes a duration in weeks, days, hours and minutes, e.g. 1d2h or 15m
+func FormatDuration(d time.Duration) string {
+	var text strings.Builder
+	for _, unit := range durationUnits {
+		if n := d / unit.size; n > 0 {
+			text.WriteString(strconv.FormatInt(int64(n), 10) + unit.symbol)
+			d -= n * unit.size
+		}
+	}
+	if text.Len() == 0 {
+		return "0m"
+	}
+
+	return text.String()
+}
+
+// ParseBefore parses how long before due time to remind, e.g. 15m, 1h30m, 2 days or 1w before
+func ParseBefore(text string) (time.Duration, error) {
+	rest := strings.TrimSpace(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(text)), "before"))
+	if rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", text)
+	}
+
+	var d time.Duration
+	for rest != "" {
+		match := durationPart.FindStringSubmatch(rest)
+		if match == nil {
+			return 0, fmt.Errorf("invalid duration %q, use e.g. 15m, 2h, 1d or 1w", text)
+		}
+
+		n, _ := strconv.ParseInt(match[1], 10, 64)
+		for _, unit := range durationUnits {
+			if strings.HasPrefix(match[2], unit.symbol) {
+				d += time.Duration(n) * unit.size
+				break
+			}
+		}
+		rest = rest[len(match[0]):]
+	}
+
+	return d, nil
+}
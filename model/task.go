@@ -1,13 +1,197 @@
 package model
 
+import (
+	"sync"
+	"time"
+)
+
 // Task represent a task - the building block of the TaskManager app
 type Task struct {
-	ID        int64  `storm:"id,increment" json:"ID"`
-	ProjectID int64  `storm:"index"        json:"ProjectID"`
-	UUID      string `storm:"unique"       json:"UUID,omitempty"`
-	Title     string `                     json:"text"`
-	Details   string `                     json:"notes"`
-	Completed bool   `storm:"index"        json:"Completed"`
-	DueDate   int64  `storm:"index"        json:"DueDate,omitempty"`
-	JiraID    string `storm:"unique"       json:"jira,omitempty"`
+	ID           int64      `storm:"id,increment" json:"ID"`
+	ProjectID    int64      `storm:"index"        json:"ProjectID"`
+	UUID         string     `storm:"unique"       json:"UUID,omitempty"`
+	Title        string     `                     json:"text"`
+	Details      string     `                     json:"notes"`
+	Completed    bool       `storm:"index"        json:"Completed"`
+	Status       string     `                     json:"Status,omitempty"`       // One of Statuses, see CurrentStatus
+	RemoteStatus string     `                     json:"RemoteStatus,omitempty"` // Status name in ticket provider
+	DueDate      int64      `storm:"index"        json:"DueDate,omitempty"`      // Midnight of the due day, in TimeZone
+	DueTime      string     `                     json:"DueTime,omitempty"`      // Time of the due day (15:04), empty for any time
+	StartDate    int64      `storm:"index"        json:"StartDate,omitempty"`    // Midnight of the day task starts, hidden until then
+	TimeZone     string     `                     json:"TimeZone,omitempty"`     // IANA name of the zone of dates, local if empty
+	Reminders    []Reminder `                     json:"Reminders,omitempty"`
+	Priority     string     `                     json:"Priority,omitempty"`
+	Tags         []string   `                     json:"Tags,omitempty"`
+	Pinned       bool       `                     json:"Pinned,omitempty"`
+	Order        int64      `                     json:"Order,omitempty"` // Manual position within the project
+	CreatedAt    time.Time  `                     json:"CreatedAt"`
+	UpdatedAt    time.Time  `                     json:"UpdatedAt"`
+	CompletedAt  *time.Time `storm:"index"        json:"CompletedAt,omitempty"`
+	DeletedAt    *time.Time `                     json:"DeletedAt,omitempty"`
+	ExternalLink `storm:"inline"`
+}
+
+// Task priorities, same as Taskwarrior's. No priority is an empty string.
+const (
+	PriorityHigh   = "H"
+	PriorityMedium = "M"
+	PriorityLow    = "L"
+)
+
+// Task statuses, the columns of project board
+const (
+	StatusTodo       = "To Do"
+	StatusInProgress = "In Progress"
+	StatusBlocked    = "Blocked"
+	StatusWaiting    = "Waiting"
+	StatusDone       = "Done"
+	StatusCancelled  = "Cancelled"
+)
+
+// Statuses are all statuses of tasks, in board order
+var Statuses = []string{StatusTodo, StatusInProgress, StatusBlocked, StatusWaiting, StatusDone, StatusCancelled}
+
+// IsClosedStatus checks if a task of the status is finished, i.e. Done or Cancelled
+func IsClosedStatus(status string) bool {
+	return status == StatusDone || status == StatusCancelled
+}
+
+// CurrentStatus is the status of the task. Completed tasks are Done, unless Cancelled.
+// Pending tasks without a (known) status, e.g. created before statuses, are To Do.
+func (t Task) CurrentStatus() string {
+	switch {
+	case t.Completed && t.Status == StatusCancelled:
+		return StatusCancelled
+	case t.Completed:
+		return StatusDone
+	case IsClosedStatus(t.Status):
+		return StatusTodo
+	}
+
+	if IsStatus(t.Status) {
+		return t.Status
+	}
+	return StatusTodo
+}
+
+// IsStatus checks if status is one of Statuses
+func IsStatus(status string) bool {
+	for _, s := range Statuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDeleted checks if the task is in Trash
+func (t Task) IsDeleted() bool {
+	return t.DeletedAt != nil
+}
+
+// clockLayout is the layout of DueTime
+const clockLayout = "15:04"
+
+// locations are the time zones of tasks loaded so far, by name
+var locations sync.Map
+
+// Location is the time zone of the task dates and due time, the local one if not set (or unknown)
+func (t Task) Location() *time.Location {
+	if t.TimeZone == "" {
+		return time.Local
+	}
+	if loc, ok := locations.Load(t.TimeZone); ok {
+		return loc.(*time.Location)
+	}
+
+	loc, err := time.LoadLocation(t.TimeZone)
+	if err != nil {
+		return time.Local
+	}
+	locations.Store(t.TimeZone, loc)
+	return loc
+}
+
+// DueDay is the day the task is due, as midnight of that date in loc. Zero if not scheduled.
+// Days are dates of the calendar, the same in any time zone, e.g. due on 3 Dec is due on 3 Dec after travelling.
+func (t Task) DueDay(loc *time.Location) time.Time {
+	return dayIn(t.DueDate, t.Location(), loc)
+}
+
+// StartDay is the day the task starts, as midnight of that date in loc. Zero if it has no start date.
+func (t Task) StartDay(loc *time.Location) time.Time {
+	return dayIn(t.StartDate, t.Location(), loc)
+}
+
+// DueAt is the moment the task is due: at its due time (of its time zone), or else the end of its due day in loc.
+// Zero if not scheduled.
+func (t Task) DueAt(loc *time.Location) time.Time {
+	if t.DueDate == 0 {
+		return time.Time{}
+	}
+
+	if clock, err := time.Parse(clockLayout, t.DueTime); err == nil {
+		year, month, day := t.DueDay(t.Location()).Date()
+		return time.Date(year, month, day, clock.Hour(), clock.Minute(), 0, 0, t.Location())
+	}
+
+	return t.DueDay(loc).AddDate(0, 0, 1).Add(-time.Second)
+}
+
+// IsOverdue checks if the task is open and past its due time, or the due day for tasks without due time
+func (t Task) IsOverdue(now time.Time) bool {
+	if t.Completed || t.DueDate == 0 {
+		return false
+	}
+	if t.DueTime != "" {
+		return t.DueAt(now.Location()).Before(now)
+	}
+
+	return t.DueDay(now.Location()).Before(dayIn(now.Unix(), now.Location(), now.Location()))
+}
+
+// IsStarted checks if the start day of the task, if any, has come by now
+func (t Task) IsStarted(now time.Time) bool {
+	return t.StartDate == 0 || !t.StartDay(now.Location()).After(now)
+}
+
+// SetDueDay sets the day the task is due (zero to unset, along with due time), as the same date in its time zone
+func (t *Task) SetDueDay(day time.Time) {
+	t.DueDate = t.dayUnix(day)
+	if t.DueDate == 0 {
+		t.DueTime = ""
+	}
+}
+
+// SetStartDay sets the day the task starts (zero to unset), as the same date in its time zone
+func (t *Task) SetStartDay(day time.Time) {
+	t.StartDate = t.dayUnix(day)
+}
+
+// SetTimeZone sets the time zone of the task (empty for local), keeping dates of its days and its due time
+func (t *Task) SetTimeZone(name string) {
+	due, start := t.DueDay(time.UTC), t.StartDay(time.UTC)
+	t.TimeZone = name
+	t.DueDate = t.dayUnix(due)
+	t.StartDate = t.dayUnix(start)
+}
+
+// dayUnix is the Unix time a day is kept as, midnight of its date in the task time zone. 0 for zero time.
+func (t Task) dayUnix(day time.Time) int64 {
+	if day.IsZero() {
+		return 0
+	}
+
+	year, month, date := day.Date()
+	return time.Date(year, month, date, 0, 0, 0, 0, t.Location()).Unix()
+}
+
+// dayIn is the date of a day (kept as Unix time of its midnight in from) as midnight in loc. Zero for 0.
+func dayIn(unix int64, from, loc *time.Location) time.Time {
+	if unix == 0 {
+		return time.Time{}
+	}
+
+	year, month, day := time.Unix(unix, 0).In(from).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
 }
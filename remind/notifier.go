@@ -0,0 +1,118 @@
+package remind
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Kinds of notifiers, chosen by REMIND_NOTIFIER
+const (
+	NotifierNotifySend = "notify-send"
+	NotifierBell       = "bell"
+	NotifierHook       = "hook"
+)
+
+// Notifiers are the kinds of notifiers
+var Notifiers = []string{NotifierNotifySend, NotifierBell, NotifierHook}
+
+// commandTimeout limits how long a notification command or hook may run, not to hold up other reminders
+const commandTimeout = 30 * time.Second
+
+// Notifier tells user about a reminder
+type Notifier interface {
+	Notify(note Notification) error
+}
+
+// NewNotifier makes the notifier of a kind. Command is the executable of notify-send (e.g. a script with
+// the same arguments), or the shell command of hook. Bell writes to out.
+// Without kind, it's notify-send if installed, bell otherwise.
+func NewNotifier(kind, command string, out io.Writer) (Notifier, error) {
+	switch ResolveKind(kind, command) {
+	case NotifierNotifySend:
+		if command == "" {
+			command = NotifierNotifySend
+		}
+		return NotifySend{Path: command}, nil
+	case NotifierBell:
+		return Bell{Out: out}, nil
+	case NotifierHook:
+		if command == "" {
+			return nil, fmt.Errorf("hook notifier needs a shell command, set REMIND_COMMAND")
+		}
+		return Hook{Command: command}, nil
+	}
+
+	return nil, fmt.Errorf("unknown notifier %q, use one of %s", kind, strings.Join(Notifiers, ", "))
+}
+
+// ResolveKind is the kind of notifier to use, notify-send or bell when not chosen
+func ResolveKind(kind, command string) string {
+	if kind != "" {
+		return kind
+	}
+	if command != "" {
+		return NotifierNotifySend
+	}
+	if _, err := exec.LookPath(NotifierNotifySend); err == nil {
+		return NotifierNotifySend
+	}
+
+	return NotifierBell
+}
+
+// NotifySend shows desktop notifications by notify-send, or an executable taking the same arguments
+type NotifySend struct {
+	Path string
+}
+
+// Notify runs `notify-send --app-name=geek-life <title> <message>`
+func (n NotifySend) Notify(note Notification) error {
+	return run(nil, n.Path, "--app-name=geek-life", note.Title, note.Message)
+}
+
+// Bell rings the terminal bell and writes the reminder
+type Bell struct {
+	Out io.Writer
+}
+
+// Notify writes the bell character and reminder line, e.g. "⏰ Call Alex - due Friday 15:00"
+func (b Bell) Notify(note Notification) error {
+	_, err := fmt.Fprintf(b.Out, "\a⏰ %s - %s\n", note.Title, note.Message)
+	return err
+}
+
+// Hook runs a shell command, with the reminder in environment variables:
+// GEEK_LIFE_TITLE, GEEK_LIFE_MESSAGE, GEEK_LIFE_TASK_UUID, GEEK_LIFE_TASK_LINK and GEEK_LIFE_DUE (RFC 3339, if due)
+type Hook struct {
+	Command string
+}
+
+// Notify runs the hook command
+func (h Hook) Notify(note Notification) error {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	return run(append(os.Environ(), note.env()...), shell, flag, h.Command)
+}
+
+// run runs a notification command (with env, nil for the current one), with its output in the error if it fails
+func run(env []string, name string, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = env
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %v %s", name, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
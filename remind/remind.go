@@ -0,0 +1,271 @@
+// Package remind finds reminders of tasks coming up and notifies of them
+// by desktop notifications (notify-send), terminal bell or a shell hook.
+package remind
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// maxMissed limits how old reminders missed while not checking (e.g. computer was off) are notified of
+const maxMissed = 24 * time.Hour
+
+// maxBackoff limits how long notifying is put off after it failed many times in a row
+const maxBackoff = time.Hour
+
+// Config controls when and how reminders are notified
+type Config struct {
+	Notifier  string        // Kind of notifier (see Notifiers). Empty for notify-send if installed, or else bell.
+	Command   string        // Executable of notify-send, or shell command of hook
+	Interval  time.Duration // How often to check for reminders
+	DayStart  time.Duration // Time of the day reminders before tasks due any time of a day count from
+	StateFile string        // File keeping when reminders were checked last
+}
+
+// ConfigFromEnv reads reminder configuration from environment variables
+func ConfigFromEnv() Config {
+	dayStart := 9 * time.Hour
+	if clock, err := time.Parse("15:04", util.GetEnvStr("REMIND_DAY_START", "09:00")); err == nil {
+		dayStart = time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute
+	}
+
+	return Config{
+		Notifier:  util.GetEnvStr("REMIND_NOTIFIER", ""),
+		Command:   util.GetEnvStr("REMIND_COMMAND", ""),
+		Interval:  time.Duration(util.GetEnvInt("REMIND_INTERVAL_SECONDS", 60)) * time.Second,
+		DayStart:  dayStart,
+		StateFile: filepath.Join(util.AppDir(), "remind.last"),
+	}
+}
+
+// Notification is a reminder of a task that has come
+type Notification struct {
+	Task    model.Task
+	At      time.Time // When the reminder was set to
+	Title   string    // Title of the task
+	Message string    // When the task is due, e.g. "Due Friday, 23 Oct 15:00"
+}
+
+func newNotification(task model.Task, at time.Time) Notification {
+	message := "Reminder"
+	if task.DueDate != 0 {
+		message = "Due " + task.DueDay(at.Location()).Format("Monday, 02 Jan")
+		if task.DueTime != "" {
+			message += task.DueAt(at.Location()).In(at.Location()).Format(" 15:04")
+		}
+	}
+
+	return Notification{Task: task, At: at, Title: task.Title, Message: message}
+}
+
+// env is the notification as environment variables of hook
+func (note Notification) env() []string {
+	due := ""
+	if note.Task.DueTime != "" {
+		due = note.Task.DueAt(time.Local).Format(time.RFC3339)
+	} else if note.Task.DueDate != 0 {
+		due = note.Task.DueDay(time.Local).Format(time.RFC3339)
+	}
+
+	link := ""
+	if note.Task.UUID != "" {
+		link = util.TaskLink(note.Task.UUID)
+	}
+
+	return []string{
+		"GEEK_LIFE_TITLE=" + note.Title,
+		"GEEK_LIFE_MESSAGE=" + note.Message,
+		"GEEK_LIFE_TASK_UUID=" + note.Task.UUID,
+		"GEEK_LIFE_TASK_LINK=" + link,
+		"GEEK_LIFE_DUE=" + due,
+	}
+}
+
+// Due finds reminders of open tasks that come after from, until to (inclusive), earliest first.
+// Reminders before tasks due any time of a day count from dayStart of the day, in the zone of to.
+func Due(tasks []model.Task, from, to time.Time, dayStart time.Duration) []Notification {
+	var notes []Notification
+	for _, task := range tasks {
+		if task.Completed || task.IsDeleted() {
+			continue
+		}
+
+		for _, reminder := range task.Reminders {
+			at := reminder.Time(task, dayStart, to.Location())
+			if !at.IsZero() && at.After(from) && !at.After(to) {
+				notes = append(notes, newNotification(task, at.In(to.Location())))
+			}
+		}
+	}
+
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].At.Before(notes[j].At)
+	})
+	return notes
+}
+
+// Upcoming lists reminders of open tasks from now on, until to. For tasks with many, the next one only.
+func Upcoming(tasks []model.Task, now, to time.Time, dayStart time.Duration) []Notification {
+	var notes []Notification
+	seen := make(map[int64]bool)
+	for _, note := range Due(tasks, now, to, dayStart) {
+		if !seen[note.Task.ID] {
+			seen[note.Task.ID] = true
+			notes = append(notes, note)
+		}
+	}
+
+	return notes
+}
+
+// Check notifies of reminders come since the last check until now, and records this check.
+// Without an earlier check, it's of the last interval. Returns the number of reminders notified.
+// When notifying fails, the rest are left for a later check, which starts again from the failed one.
+// Checks are skipped until then, for twice the interval, doubling with every failure in a row.
+func Check(tasks []model.Task, now time.Time, config Config, notifier Notifier) (int, error) {
+	last := readState(config.StateFile)
+	// A retry time too far off is of a changed clock
+	if now.Before(last.retryAt) && last.retryAt.Sub(now) <= maxBackoff {
+		return 0, nil
+	}
+
+	from := last.checked
+	if from.IsZero() || from.After(now) {
+		from = now.Add(-config.Interval)
+	}
+	if oldest := now.Add(-maxMissed); from.Before(oldest) {
+		from = oldest
+	}
+
+	var notifyErr error
+	next, notified := checkState{checked: now}, 0
+	for _, note := range Due(tasks, from, now, config.DayStart) {
+		if notifyErr = notifier.Notify(note); notifyErr != nil {
+			// Reminders are of whole seconds, the ones before the failed one are notified
+			next.checked = note.At.Add(-time.Second)
+			next.failures = 1
+			if next.checked.Equal(last.checked) {
+				next.failures = last.failures + 1
+			}
+			next.retryAt = now.Add(backoff(config.Interval, next.failures))
+			break
+		}
+		notified++
+	}
+
+	if err := writeState(config.StateFile, next); err != nil && notifyErr == nil {
+		return notified, err
+	}
+	return notified, notifyErr
+}
+
+// backoff is how long to wait before notifying again after failures in a row
+func backoff(interval time.Duration, failures int) time.Duration {
+	wait := interval
+	for i := 0; i < failures && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		return maxBackoff
+	}
+	return wait
+}
+
+// checkState is kept in the state file between checks. The first line is when reminders were checked until,
+// the second one (if notifying failed) is when to retry and the number of failures in a row.
+type checkState struct {
+	checked  time.Time
+	retryAt  time.Time
+	failures int
+}
+
+func readState(stateFile string) checkState {
+	var state checkState
+	content, err := ioutil.ReadFile(stateFile)
+	if err != nil {
+		return state
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if state.checked, err = time.Parse(time.RFC3339, strings.TrimSpace(lines[0])); err != nil {
+		return checkState{}
+	}
+	if len(lines) > 1 {
+		if fields := strings.Fields(lines[1]); len(fields) == 2 {
+			retryAt, timeErr := time.Parse(time.RFC3339, fields[0])
+			failures, countErr := strconv.Atoi(fields[1])
+			if timeErr == nil && countErr == nil {
+				state.retryAt, state.failures = retryAt, failures
+			}
+		}
+	}
+	return state
+}
+
+func writeState(stateFile string, state checkState) error {
+	content := state.checked.Format(time.RFC3339) + "\n"
+	if state.failures > 0 {
+		content += fmt.Sprintf("%s %d\n", state.retryAt.Format(time.RFC3339), state.failures)
+	}
+
+	util.CreateDirIfNotExist(filepath.Dir(stateFile))
+	if err := ioutil.WriteFile(stateFile, []byte(content), 0600); err != nil {
+		return fmt.Errorf("could not save reminder state: %w", err)
+	}
+
+	return nil
+}
+
+// LastChecked reads when reminders were checked last, zero if never
+func LastChecked(stateFile string) time.Time {
+	return readState(stateFile).checked
+}
+
+// SaveChecked records when reminders were checked, for the next check to notify the ones come since then
+func SaveChecked(stateFile string, checked time.Time) error {
+	return writeState(stateFile, checkState{checked: checked})
+}
+
+// Schedule checks reminders of the tasks from load right away and then at every interval, until stop is called.
+// Errors of loading and notifying are passed to onError.
+func Schedule(config Config, load func() ([]model.Task, error), notifier Notifier, onError func(error)) (stop func()) {
+	if config.Interval <= 0 {
+		return func() {}
+	}
+
+	check := func() {
+		tasks, err := load()
+		if err == nil {
+			_, err = Check(tasks, time.Now(), config, notifier)
+		}
+		if err != nil {
+			onError(err)
+		}
+	}
+
+	done := make(chan struct{})
+	ticker := time.NewTicker(config.Interval)
+
+	go func() {
+		check()
+		for {
+			select {
+			case <-ticker.C:
+				check()
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
@@ -0,0 +1,362 @@
+package remind
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+)
+
+const dayStart = 9 * time.Hour
+
+var now = time.Date(2025, time.March, 7, 12, 0, 0, 0, time.UTC) // Friday noon
+
+// dueTask is a task due on a day, at clock if not empty, with reminders before it
+func dueTask(id int64, title string, due time.Time, clock string, before ...time.Duration) model.Task {
+	task := model.Task{ID: id, UUID: title + "-uuid", Title: title, TimeZone: "UTC", DueTime: clock}
+	task.SetDueDay(due)
+	for _, b := range before {
+		task.Reminders = append(task.Reminders, model.Reminder{Before: b})
+	}
+	return task
+}
+
+// atTask is a task with reminders at moments
+func atTask(id int64, title string, at ...time.Time) model.Task {
+	task := model.Task{ID: id, Title: title, TimeZone: "UTC"}
+	for _, t := range at {
+		task.Reminders = append(task.Reminders, model.Reminder{At: t.Unix()})
+	}
+	return task
+}
+
+func titles(notes []Notification) string {
+	var names []string
+	for _, note := range notes {
+		names = append(names, note.Title+"@"+note.At.Format("Jan 2 15:04"))
+	}
+	return strings.Join(names, ", ")
+}
+
+func TestDue(t *testing.T) {
+	today := time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC)
+	completed := atTask(5, "completed", now.Add(-time.Minute))
+	completed.Completed = true
+	deleted := atTask(6, "deleted", now.Add(-time.Minute))
+	deleted.DeletedAt = &now
+
+	tasks := []model.Task{
+		// All day tasks count from dayStart: 1h before is 08:00 of the due day
+		dueTask(1, "all-day", today, "", time.Hour),
+		// Tasks with due time count from it: 30m before 12:30 is 12:00
+		dueTask(2, "timed", today, "12:30", 30*time.Minute),
+		// At due time of an all day task is dayStart
+		dueTask(3, "at-due", today, "", 0),
+		atTask(4, "at", now.Add(-2*time.Hour), now.Add(time.Hour)),
+		completed,
+		deleted,
+		// Reminders before unscheduled tasks never come
+		{ID: 7, Title: "unscheduled", Reminders: []model.Reminder{{Before: time.Hour}}},
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     string
+	}{
+		{"morning", today, now, "all-day@Mar 7 08:00, at-due@Mar 7 09:00, at@Mar 7 10:00, timed@Mar 7 12:00"},
+		{"from is excluded", today.Add(8 * time.Hour), now, "at-due@Mar 7 09:00, at@Mar 7 10:00, timed@Mar 7 12:00"},
+		{"to is included", now.Add(-time.Minute), now, "timed@Mar 7 12:00"},
+		{"just before", today.Add(7 * time.Hour), today.Add(8*time.Hour - time.Second), ""},
+		{"later", now, now.Add(2 * time.Hour), "at@Mar 7 13:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := titles(Due(tasks, tt.from, tt.to, dayStart)); got != tt.want {
+				t.Errorf("Due() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDueDayStartInZone(t *testing.T) {
+	dhaka, err := time.LoadLocation("Asia/Dhaka")
+	if err != nil {
+		t.Skip("no time zone data")
+	}
+
+	// An all day task is due on its date in any zone, reminded at dayStart there
+	task := dueTask(1, "all-day", time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC), "", 0)
+	notes := Due([]model.Task{task}, now.Add(-24*time.Hour), now, dayStart)
+	if len(notes) != 1 {
+		t.Fatalf("Due() = %q, want one", titles(notes))
+	}
+	want := time.Date(2025, time.March, 7, 9, 0, 0, 0, dhaka)
+	if got := Due([]model.Task{task}, now.Add(-24*time.Hour), now.In(dhaka), dayStart); len(got) != 1 || !got[0].At.Equal(want) {
+		t.Errorf("Due() in Dhaka = %q, want at %s", titles(got), want)
+	}
+}
+
+func TestUpcoming(t *testing.T) {
+	tomorrow := time.Date(2025, time.March, 8, 0, 0, 0, 0, time.UTC)
+	tasks := []model.Task{
+		dueTask(1, "many", tomorrow, "10:00", 0, time.Hour, 24*time.Hour),
+		atTask(2, "past", now.Add(-time.Hour)),
+		atTask(3, "far", now.AddDate(0, 0, 10)),
+	}
+
+	// 24h before (Mar 7 10:00) has passed, 1h before is the next one
+	got := titles(Upcoming(tasks, now, now.AddDate(0, 0, 7), dayStart))
+	if want := "many@Mar 8 09:00"; got != want {
+		t.Errorf("Upcoming() = %q, want %q", got, want)
+	}
+}
+
+// fakeNotifier records notifications, failing for titles in fail
+type fakeNotifier struct {
+	notified []string
+	fail     map[string]bool
+	attempts int
+}
+
+func (n *fakeNotifier) Notify(note Notification) error {
+	n.attempts++
+	if n.fail[note.Title] {
+		return errors.New("failed " + note.Title)
+	}
+	n.notified = append(n.notified, note.Title)
+	return nil
+}
+
+func testConfig(t *testing.T) Config {
+	return Config{Interval: time.Minute, DayStart: dayStart, StateFile: filepath.Join(t.TempDir(), "remind.last")}
+}
+
+func TestCheckWindow(t *testing.T) {
+	tasks := []model.Task{
+		atTask(1, "2 days ago", now.AddDate(0, 0, -2)),
+		atTask(2, "12 hours ago", now.Add(-12*time.Hour)),
+		atTask(3, "30 seconds ago", now.Add(-30*time.Second)),
+		atTask(4, "now", now),
+		atTask(5, "soon", now.Add(time.Minute)),
+	}
+
+	tests := []struct {
+		name      string
+		lastCheck time.Time // Zero for no state file
+		want      string
+	}{
+		{"first check is of last interval", time.Time{}, "30 seconds ago, now"},
+		{"since last check", now.Add(-13 * time.Hour), "12 hours ago, 30 seconds ago, now"},
+		{"missed ones are of a day at most", now.AddDate(0, 0, -3), "12 hours ago, 30 seconds ago, now"},
+		{"state file in the future", now.Add(time.Hour), "30 seconds ago, now"},
+		{"checked already", now, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testConfig(t)
+			if !tt.lastCheck.IsZero() {
+				if err := SaveChecked(config.StateFile, tt.lastCheck); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			notifier := &fakeNotifier{}
+			count, err := Check(tasks, now, config, notifier)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Join(notifier.notified, ", "); got != tt.want || count != len(notifier.notified) {
+				t.Errorf("Check() notified %d: %q, want %q", count, got, tt.want)
+			}
+			if checked := LastChecked(config.StateFile); !checked.Equal(now) {
+				t.Errorf("LastChecked() = %s, want %s", checked, now)
+			}
+		})
+	}
+}
+
+func TestCheckKeepsFailed(t *testing.T) {
+	config := testConfig(t)
+	tasks := []model.Task{
+		atTask(1, "first", now.Add(-40*time.Second)),
+		atTask(2, "failing", now.Add(-30*time.Second)),
+		atTask(3, "last", now.Add(-20*time.Second)),
+	}
+
+	notifier := &fakeNotifier{fail: map[string]bool{"failing": true}}
+	count, err := Check(tasks, now, config, notifier)
+	if err == nil || count != 1 || strings.Join(notifier.notified, ", ") != "first" {
+		t.Fatalf("Check() = %d, %v, notified %q; want first only and an error", count, err, notifier.notified)
+	}
+
+	// Next check (after backing off for 2 intervals) notifies the failed one and the rest, but not the notified one
+	notifier.fail = nil
+	later := now.Add(2 * time.Minute)
+	if count, err = Check(tasks, later, config, notifier); err != nil || count != 2 {
+		t.Fatalf("next Check() = %d, %v; want 2", count, err)
+	}
+	if got := strings.Join(notifier.notified, ", "); got != "first, failing, last" {
+		t.Errorf("notified %q", got)
+	}
+	if checked := LastChecked(config.StateFile); !checked.Equal(later) {
+		t.Errorf("LastChecked() = %s, want %s", checked, later)
+	}
+}
+
+func TestCheckBacksOffAfterFailure(t *testing.T) {
+	config := testConfig(t)
+	tasks := []model.Task{atTask(1, "failing", now.Add(-30*time.Second))}
+	notifier := &fakeNotifier{fail: map[string]bool{"failing": true}}
+
+	tests := []struct {
+		name     string
+		at       time.Duration // After now
+		fail     bool
+		attempts int // In total, until this check
+		wantErr  bool
+	}{
+		{"fails", 0, true, 1, true},
+		{"waits 2 intervals", time.Minute, true, 1, false},
+		{"retries", 2 * time.Minute, true, 2, true},
+		{"waits 4 intervals after 2 failures", 5 * time.Minute, true, 2, false},
+		{"retries again", 6 * time.Minute, false, 3, false},
+		{"not notified again", 7 * time.Minute, true, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier.fail["failing"] = tt.fail
+			_, err := Check(tasks, now.Add(tt.at), config, notifier)
+			if (err != nil) != tt.wantErr || notifier.attempts != tt.attempts {
+				t.Errorf("Check() error = %v, %d attempts; want error %v, %d attempts", err, notifier.attempts, tt.wantErr, tt.attempts)
+			}
+		})
+	}
+	if got := strings.Join(notifier.notified, ", "); got != "failing" {
+		t.Errorf("notified %q, want the failed one once", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{1, 2 * time.Minute},
+		{2, 4 * time.Minute},
+		{5, 32 * time.Minute},
+		{6, maxBackoff},
+		{1000, maxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(time.Minute, tt.failures); got != tt.want {
+			t.Errorf("backoff(%d failures) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+// writeScript writes an executable shell script into dir
+func writeScript(t *testing.T, dir, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, "notify")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(content)), "\n")
+}
+
+func TestCheckNotifySend(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "args")
+	script := writeScript(t, dir, `for arg in "$@"; do echo "$arg" >> "`+out+`"; done`)
+
+	notifier, err := NewNotifier(NotifierNotifySend, script, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := dueTask(1, "Call Alex", time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC), "12:15", 15*time.Minute)
+	if count, err := Check([]model.Task{task}, now, testConfig(t), notifier); err != nil || count != 1 {
+		t.Fatalf("Check() = %d, %v", count, err)
+	}
+
+	want := []string{"--app-name=geek-life", "Call Alex", "Due Friday, 07 Mar 12:15"}
+	if got := readLines(t, out); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("notify-send got arguments %q, want %q", got, want)
+	}
+}
+
+func TestCheckHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "env")
+
+	notifier, err := NewNotifier(NotifierHook, `env | grep ^GEEK_LIFE_ | sort > "`+out+`"`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := dueTask(1, "Call Alex", time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC), "12:15", 15*time.Minute)
+	task.UUID = "0b6f0f3e-2a5c-4f4e-9b1a-3c2d1e0f9a8b"
+	if count, err := Check([]model.Task{task}, now, testConfig(t), notifier); err != nil || count != 1 {
+		t.Fatalf("Check() = %d, %v", count, err)
+	}
+
+	want := map[string]string{
+		"GEEK_LIFE_TITLE":     "Call Alex",
+		"GEEK_LIFE_MESSAGE":   "Due Friday, 07 Mar 12:15",
+		"GEEK_LIFE_TASK_UUID": task.UUID,
+		"GEEK_LIFE_DUE":       "2025-03-07T12:15:00Z",
+	}
+	got := make(map[string]string)
+	for _, line := range readLines(t, out) {
+		if kv := strings.SplitN(line, "=", 2); len(kv) == 2 {
+			got[kv[0]] = kv[1]
+		}
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %q, want %q", key, got[key], value)
+		}
+	}
+	if !strings.Contains(got["GEEK_LIFE_TASK_LINK"], task.UUID) {
+		t.Errorf("GEEK_LIFE_TASK_LINK = %q, want a link to the task", got["GEEK_LIFE_TASK_LINK"])
+	}
+}
+
+func TestCheckHookFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	config := testConfig(t)
+	notifier := Hook{Command: "echo no display >&2; exit 3"}
+	task := atTask(1, "Call Alex", now.Add(-10*time.Second))
+
+	if _, err := Check([]model.Task{task}, now, config, notifier); err == nil || !strings.Contains(err.Error(), "no display") {
+		t.Errorf("Check() error = %v, want the output of the hook", err)
+	}
+	if checked := LastChecked(config.StateFile); !checked.Before(task.Reminders[0].Time(task, dayStart, time.UTC)) {
+		t.Errorf("LastChecked() = %s, want before the failed reminder", checked)
+	}
+}
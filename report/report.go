@@ -0,0 +1,181 @@
+// Package report builds Markdown status reports (daily standup, weekly summary) from tasks,
+// using Go templates that can be customised in ~/.geek-life/templates
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/stats"
+	"github.com/ajaxray/geek-life/ticketmanager"
+)
+
+// Item is a task, as displayed in reports
+type Item struct {
+	Title       string
+	Project     string
+	TicketKey   string
+	TicketURL   string
+	Status      string // One of model.Statuses
+	DueDate     *time.Time
+	CompletedAt *time.Time
+}
+
+// ProjectGroup is a project with its tasks, as displayed in reports
+type ProjectGroup struct {
+	Title     string
+	TicketKey string
+	TicketURL string
+	Items     []Item
+}
+
+// Standup is the data of daily standup report
+type Standup struct {
+	Date    time.Time
+	Since   time.Time // Start of previous working day
+	Done    []Item    // Completed since previous working day
+	Today   []Item    // Open tasks due today
+	Blocked []Item    // Open tasks Blocked or Waiting, or past their due date
+}
+
+// Weekly is the data of weekly status report
+type Weekly struct {
+	From      time.Time // Monday of the week
+	To        time.Time // Sunday of the week
+	Completed int
+	Projects  []ProjectGroup // Projects with completed tasks, with those tasks
+}
+
+// BuildStandup collects the tasks for standup report of the day of date
+func BuildStandup(tasks []model.Task, projects []model.Project, date time.Time) Standup {
+	today := stats.StartOfDay(date)
+	tomorrow := today.AddDate(0, 0, 1)
+	report := Standup{Date: today, Since: previousWorkingDay(today)}
+
+	projectByID := mapProjects(projects)
+	for _, task := range tasks {
+		project, ok := projectByID[task.ProjectID]
+		if !ok {
+			continue
+		}
+
+		item := makeItem(task, project)
+		switch {
+		case task.CompletedAt != nil:
+			if isDone(task) && !task.CompletedAt.Before(report.Since) && task.CompletedAt.Before(tomorrow) {
+				report.Done = append(report.Done, item)
+			}
+		case task.Completed:
+			// Completed before timestamps were recorded
+		case item.Status == model.StatusBlocked || item.Status == model.StatusWaiting:
+			report.Blocked = append(report.Blocked, item)
+		case task.DueDate == 0:
+			// Not scheduled
+		case task.DueDay(today.Location()).Before(today):
+			report.Blocked = append(report.Blocked, item)
+		case task.DueDay(today.Location()).Before(tomorrow):
+			report.Today = append(report.Today, item)
+		}
+	}
+
+	sortItems(report.Done)
+	sortItems(report.Today)
+	sortItems(report.Blocked)
+	return report
+}
+
+// BuildWeekly collects tasks completed in the week (Monday to Sunday) of date, grouped by project
+func BuildWeekly(tasks []model.Task, projects []model.Project, date time.Time) Weekly {
+	from := stats.StartOfWeek(date)
+	to := from.AddDate(0, 0, 7)
+	report := Weekly{From: from, To: to.AddDate(0, 0, -1)}
+
+	projectByID := mapProjects(projects)
+	groups := make(map[int64]*ProjectGroup)
+	for _, task := range tasks {
+		project, ok := projectByID[task.ProjectID]
+		if !ok || !isDone(task) || task.CompletedAt == nil || task.CompletedAt.Before(from) || !task.CompletedAt.Before(to) {
+			continue
+		}
+
+		group, ok := groups[project.ID]
+		if !ok {
+			group = &ProjectGroup{
+				Title:     project.Title,
+				TicketKey: project.ExternalKey,
+				TicketURL: linkURL(project.ExternalLink),
+			}
+			groups[project.ID] = group
+		}
+		group.Items = append(group.Items, makeItem(task, project))
+		report.Completed++
+	}
+
+	for _, group := range groups {
+		sortItems(group.Items)
+		report.Projects = append(report.Projects, *group)
+	}
+	sort.Slice(report.Projects, func(i, j int) bool { return report.Projects[i].Title < report.Projects[j].Title })
+
+	return report
+}
+
+// isDone checks if a task is completed, not cancelled
+func isDone(task model.Task) bool {
+	return task.Completed && task.CurrentStatus() != model.StatusCancelled
+}
+
+func makeItem(task model.Task, project model.Project) Item {
+	item := Item{
+		Title:       task.Title,
+		Project:     project.Title,
+		TicketKey:   task.ExternalKey,
+		TicketURL:   linkURL(task.ExternalLink),
+		Status:      task.CurrentStatus(),
+		CompletedAt: task.CompletedAt,
+	}
+	if task.DueDate != 0 {
+		due := task.DueDay(time.Local)
+		item.DueDate = &due
+	}
+
+	return item
+}
+
+func linkURL(link model.ExternalLink) string {
+	if !link.IsLinked() {
+		return ""
+	}
+
+	return ticketmanager.LinkURL(link)
+}
+
+func mapProjects(projects []model.Project) map[int64]model.Project {
+	projectByID := make(map[int64]model.Project, len(projects))
+	for _, project := range projects {
+		projectByID[project.ID] = project
+	}
+
+	return projectByID
+}
+
+// sortItems orders items by project, then by title
+func sortItems(items []Item) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Project != items[j].Project {
+			return items[i].Project < items[j].Project
+		}
+		return items[i].Title < items[j].Title
+	})
+}
+
+// previousWorkingDay finds the last weekday before day. Done items of Friday are reported on Monday.
+func previousWorkingDay(day time.Time) time.Time {
+	previous := day.AddDate(0, 0, -1)
+	for previous.Weekday() == time.Saturday || previous.Weekday() == time.Sunday {
+		previous = previous.AddDate(0, 0, -1)
+	}
+
+	return previous
+}
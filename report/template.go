@@ -0,0 +1,104 @@
+package report
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/ajaxray/geek-life/util"
+)
+
+// Names of the report templates
+const (
+	StandupTemplate = "standup"
+	WeeklyTemplate  = "weekly"
+)
+
+const templateExt = ".md.tmpl"
+
+//go:embed templates/*.md.tmpl
+var defaultTemplates embed.FS
+
+var templateFuncs = template.FuncMap{
+	// link makes a Markdown link of a ticket key, or the plain key without URL
+	"link": func(key, url string) string {
+		if url == "" {
+			return key
+		}
+		return fmt.Sprintf("[%s](%s)", key, url)
+	},
+	"date": func(t interface{}) string { return formatTime(t, "2006-01-02") },
+	"day":  func(t interface{}) string { return formatTime(t, "Monday") },
+}
+
+// Render executes the named template with data.
+// A custom template (e.g. ~/.geek-life/templates/standup.md.tmpl) is used if found, otherwise the default one.
+func Render(name string, data interface{}) (string, error) {
+	content, err := os.ReadFile(filepath.Join(util.TemplateDir(), name+templateExt))
+	if os.IsNotExist(err) {
+		content, err = defaultTemplates.ReadFile("templates/" + name + templateExt)
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not read template %s: %w", name, err)
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return "", fmt.Errorf("invalid template %s: %w", name, err)
+	}
+
+	var output bytes.Buffer
+	if err := tmpl.Execute(&output, data); err != nil {
+		return "", fmt.Errorf("could not render template %s: %w", name, err)
+	}
+
+	return output.String(), nil
+}
+
+// WriteDefaultTemplates copies default templates to util.TemplateDir for customisation.
+// Existing templates are not overwritten. Returns paths of the written files.
+func WriteDefaultTemplates() ([]string, error) {
+	dir := util.TemplateDir()
+	util.CreateDirIfNotExist(dir)
+
+	entries, err := defaultTemplates.ReadDir("templates")
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+
+		content, err := defaultTemplates.ReadFile("templates/" + entry.Name())
+		if err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+func formatTime(t interface{}, layout string) string {
+	switch value := t.(type) {
+	case time.Time:
+		return value.Format(layout)
+	case *time.Time:
+		if value != nil {
+			return value.Format(layout)
+		}
+	}
+
+	return ""
+}
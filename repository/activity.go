@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+)
+
+// ActivityRepository interface defines methods of task history accessor.
+// Activities are recorded by TaskRepository and ProjectRepository and never modified.
+type ActivityRepository interface {
+	GetAllByTask(taskID int64) ([]model.Activity, error)
+	GetAllByDateRange(from, to time.Time) ([]model.Activity, error)
+}
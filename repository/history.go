@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asdine/storm/v3"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/util"
+)
+
+// ErrNotFound is returned when a record is not found. Same as storm's, which the app checks for.
+var ErrNotFound = storm.ErrNotFound
+
+// trackedTaskFields are the Task fields recorded in history when changed
+var trackedTaskFields = []struct {
+	name  string
+	value func(task model.Task) string
+}{
+	{"Title", func(task model.Task) string { return task.Title }},
+	{"Details", func(task model.Task) string { return task.Details }},
+	{"Completed", func(task model.Task) string { return strconv.FormatBool(task.Completed) }},
+	{"Status", func(task model.Task) string { return task.Status }},
+	{"DueDate", func(task model.Task) string { return formatDay(task.DueDay(time.Local)) }},
+	{"DueTime", func(task model.Task) string { return task.DueTime }},
+	{"StartDate", func(task model.Task) string { return formatDay(task.StartDay(time.Local)) }},
+	{"TimeZone", func(task model.Task) string { return task.TimeZone }},
+	{"Reminders", func(task model.Task) string { return formatReminders(task.Reminders) }},
+	{"Priority", func(task model.Task) string { return task.Priority }},
+	{"Tags", func(task model.Task) string { return strings.Join(task.Tags, ", ") }},
+	{"Pinned", func(task model.Task) string { return strconv.FormatBool(task.Pinned) }},
+	{"ProjectID", func(task model.Task) string { return strconv.FormatInt(task.ProjectID, 10) }},
+	{"ExternalKey", func(task model.Task) string { return task.ExternalKey }},
+}
+
+// DiffTask makes an "updated" Activity for every tracked field that differs between previous and current
+func DiffTask(previous, current model.Task) []model.Activity {
+	var activities []model.Activity
+	for _, field := range trackedTaskFields {
+		oldValue, newValue := field.value(previous), field.value(current)
+		if oldValue != newValue {
+			activities = append(activities, model.Activity{
+				Action:   model.ActivityUpdated,
+				Field:    field.name,
+				OldValue: oldValue,
+				NewValue: newValue,
+			})
+		}
+	}
+
+	return activities
+}
+
+// DiffProject makes an "updated" Activity of Title when the project is renamed
+func DiffProject(previous, current model.Project) []model.Activity {
+	var activities []model.Activity
+	if previous.Title != current.Title {
+		activities = append(activities, model.Activity{
+			Action:   model.ActivityUpdated,
+			Field:    "Title",
+			OldValue: previous.Title,
+			NewValue: current.Title,
+		})
+	}
+
+	return activities
+}
+
+func formatReminders(reminders []model.Reminder) string {
+	texts := make([]string, 0, len(reminders))
+	for _, reminder := range reminders {
+		texts = append(texts, reminder.String())
+	}
+
+	return strings.Join(texts, ", ")
+}
+
+func formatDay(day time.Time) string {
+	if day.IsZero() {
+		return ""
+	}
+
+	return day.Format("2006-01-02")
+}
+
+// StampCompletion sets or clears CompletedAt when a task is completed or reopened.
+// previous is the stored state of the task, nil for new tasks.
+func StampCompletion(previous, task *model.Task) {
+	wasCompleted := previous != nil && previous.Completed
+	if task.Completed && !wasCompleted && task.CompletedAt == nil {
+		now := time.Now()
+		task.CompletedAt = &now
+	} else if !task.Completed {
+		task.CompletedAt = nil
+	}
+}
+
+// StampTimeZone sets the time zone of a task with dates to the local one, if not set yet.
+// Dates are kept in the zone they are set in, so they stay the same dates in other time zones.
+func StampTimeZone(task *model.Task) {
+	if task.TimeZone == "" && (task.DueDate != 0 || task.StartDate != 0) {
+		task.TimeZone = util.LocalZoneName()
+	}
+}
+
+// StampUUID gives a record without UUID a new one. Called on every save, so no record is stored without UUID.
+func StampUUID(uuid *string) {
+	if *uuid == "" {
+		*uuid = util.NewUUID()
+	}
+}
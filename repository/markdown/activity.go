@@ -0,0 +1,51 @@
+package markdown
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/repository"
+)
+
+type activityRepository struct {
+	store *Store
+}
+
+// NewActivityRepository will create an object that represent the repository.Activity interface
+func NewActivityRepository(store *Store) repository.ActivityRepository {
+	return &activityRepository{store}
+}
+
+func (repo *activityRepository) GetAllByTask(taskID int64) ([]model.Activity, error) {
+	return repo.find(func(activity model.Activity) bool { return activity.TaskID == taskID })
+}
+
+func (repo *activityRepository) GetAllByDateRange(from, to time.Time) ([]model.Activity, error) {
+	return repo.find(func(activity model.Activity) bool {
+		return !activity.CreatedAt.Before(from) && !activity.CreatedAt.After(to)
+	})
+}
+
+// find lists the activities accepted by match, oldest first
+func (repo *activityRepository) find(match func(activity model.Activity) bool) ([]model.Activity, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	all, err := repo.store.readHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	var activities []model.Activity
+	for _, activity := range all {
+		if match(activity) {
+			activities = append(activities, activity)
+		}
+	}
+
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].CreatedAt.Before(activities[j].CreatedAt)
+	})
+	return activities, nil
+}
@@ -0,0 +1,198 @@
+package markdown
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/repository"
+)
+
+type projectRepository struct {
+	store  *Store
+	source string // Recorded as source of the changes in project history
+}
+
+// NewProjectRepository will create an object that represent the repository.Project interface.
+// Changes are recorded as made from the TUI, use WithSource for other sources.
+func NewProjectRepository(store *Store) repository.ProjectRepository {
+	return &projectRepository{store, model.ActivitySourceTUI}
+}
+
+func (repo *projectRepository) WithSource(source string) repository.ProjectRepository {
+	return &projectRepository{repo.store, source}
+}
+
+func (repo *projectRepository) GetAll() ([]model.Project, error) {
+	return repo.find(func(project model.Project) bool { return !project.IsDeleted() })
+}
+
+func (repo *projectRepository) GetAllDeleted() ([]model.Project, error) {
+	return repo.find(func(project model.Project) bool { return project.IsDeleted() })
+}
+
+func (repo *projectRepository) GetAllSortedByRemoteDate() ([]model.Project, error) {
+	projects, err := repo.GetAll()
+	if err != nil {
+		return projects, err
+	}
+
+	repository.SortProjectsByRemoteDate(projects)
+	return projects, nil
+}
+
+func (repo *projectRepository) GetByID(id int64) (model.Project, error) {
+	return repo.findOne(func(project model.Project) bool { return project.ID == id })
+}
+
+func (repo *projectRepository) GetByTitle(title string) (model.Project, error) {
+	return repo.findOne(func(project model.Project) bool { return project.Title == title })
+}
+
+func (repo *projectRepository) GetByUUID(UUID string) (model.Project, error) {
+	return repo.findOne(func(project model.Project) bool { return project.UUID == UUID })
+}
+
+func (repo *projectRepository) Create(title, UUID string) (model.Project, error) {
+	project := model.Project{
+		Title: title,
+		UUID:  UUID,
+	}
+
+	err := repo.create(&project)
+	return project, err
+}
+
+func (repo *projectRepository) CreateWithLink(title string, link model.ExternalLink) (model.Project, error) {
+	project := model.Project{
+		Title:        title,
+		ExternalLink: link,
+	}
+
+	err := repo.create(&project)
+	return project, err
+}
+
+func (repo *projectRepository) Update(project *model.Project) error {
+	return repo.update(project)
+}
+
+func (repo *projectRepository) UpdateField(project *model.Project, field string, value interface{}) error {
+	updated := *project
+	fieldValue := reflect.ValueOf(&updated).Elem().FieldByName(field)
+	if !fieldValue.IsValid() || reflect.ValueOf(value).Kind() != fieldValue.Kind() {
+		return repository.ErrNotFound
+	}
+	fieldValue.Set(reflect.ValueOf(value))
+
+	if err := repo.Update(&updated); err != nil {
+		return err
+	}
+
+	*project = updated
+	return nil
+}
+
+func (repo *projectRepository) SoftDelete(project *model.Project, at time.Time) error {
+	project.DeletedAt = &at
+	return repo.update(project, model.Activity{Action: model.ActivityDeleted})
+}
+
+func (repo *projectRepository) Restore(project *model.Project) error {
+	project.DeletedAt = nil
+	return repo.update(project, model.Activity{Action: model.ActivityRestored})
+}
+
+func (repo *projectRepository) Delete(project *model.Project) error {
+	return repo.change(func(snap *snapshot) ([]model.Activity, error) {
+		return []model.Activity{{Action: model.ActivityPurged, OldValue: project.Title}}, repo.store.deleteProject(snap, *project)
+	}, project)
+}
+
+func (repo *projectRepository) SearchProjects(query string) ([]model.Project, error) {
+	lowerQuery := strings.ToLower(query)
+	return repo.find(func(project model.Project) bool {
+		// Search in title and ticket key
+		return !project.IsDeleted() &&
+			(strings.Contains(strings.ToLower(project.Title), lowerQuery) ||
+				strings.Contains(strings.ToLower(project.ExternalKey), lowerQuery))
+	})
+}
+
+func (repo *projectRepository) create(project *model.Project) error {
+	return repo.change(func(snap *snapshot) ([]model.Activity, error) {
+		project.ID = snap.nextProjectID()
+		repository.StampUUID(&project.UUID)
+		return []model.Activity{{Action: model.ActivityCreated, NewValue: project.Title}}, repo.store.saveProject(snap, *project)
+	}, project)
+}
+
+// update saves a stored project, recording its changes along with activities
+func (repo *projectRepository) update(project *model.Project, activities ...model.Activity) error {
+	return repo.change(func(snap *snapshot) ([]model.Activity, error) {
+		stored, ok := snap.project(project.ID)
+		if !ok {
+			return nil, repository.ErrNotFound
+		}
+
+		repository.StampUUID(&project.UUID)
+		return append(repository.DiffProject(stored, *project), activities...), repo.store.saveProject(snap, *project)
+	}, project)
+}
+
+// find lists the projects accepted by match, ordered by ID
+func (repo *projectRepository) find(match func(project model.Project) bool) ([]model.Project, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	snap, err := repo.store.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []model.Project
+	for _, project := range snap.projects {
+		if match(project) {
+			projects = append(projects, project)
+		}
+	}
+
+	return projects, nil
+}
+
+func (repo *projectRepository) findOne(match func(project model.Project) bool) (model.Project, error) {
+	projects, err := repo.find(match)
+	if err != nil {
+		return model.Project{}, err
+	}
+	if len(projects) == 0 {
+		return model.Project{}, repository.ErrNotFound
+	}
+
+	return projects[0], nil
+}
+
+// change runs apply on fresh content of the store and records the activities it returns in project history
+func (repo *projectRepository) change(apply func(snap *snapshot) ([]model.Activity, error), project *model.Project) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	snap, err := repo.store.load()
+	if err != nil {
+		return err
+	}
+
+	activities, err := apply(snap)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range activities {
+		activities[i].ProjectID = project.ID
+		activities[i].Source = repo.source
+		activities[i].CreatedAt = now
+	}
+	return repo.store.appendHistory(activities)
+}
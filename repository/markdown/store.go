@@ -0,0 +1,683 @@
+// Package markdown stores projects and tasks as plain files, to grep, edit and version them with any tool.
+// Every project is a directory and every task is a Markdown file (the task note) with YAML front matter:
+//
+//	~/.geek-life/notes/
+//	├── .history.jsonl          History of tasks and projects, one activity per line
+//	└── home-renovation/
+//	    ├── _project.md         Attributes and description of the project
+//	    └── paint-the-fence.md  A task
+//
+// The directory is read on every access, so changes made outside geek-life are picked up right away.
+// Files created by hand get an ID (and UUID) written into their front matter on first read.
+package markdown
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/repository"
+	"github.com/ajaxray/geek-life/util"
+)
+
+const (
+	projectFile     = "_project.md"
+	historyFile     = ".history.jsonl"
+	taskExt         = ".md"
+	dateLayout      = "2006-01-02"
+	frontMatterLine = "---"
+	maxSlugLength   = 60
+)
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Store is a directory of projects and tasks, shared by the repositories
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// Open makes a Store of dir, creating the directory if not exists
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &Store{dir: dir}, nil
+}
+
+// Dir is the directory of the store
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// linkMeta is model.ExternalLink in front matter
+type linkMeta struct {
+	Provider        string     `yaml:"provider,omitempty"`
+	Ticket          string     `yaml:"ticket,omitempty"`
+	TicketID        string     `yaml:"ticket_id,omitempty"`
+	TicketURL       string     `yaml:"ticket_url,omitempty"`
+	RemoteCreatedAt *time.Time `yaml:"remote_created_at,omitempty"`
+	RemoteUpdatedAt *time.Time `yaml:"remote_updated_at,omitempty"`
+}
+
+// projectMeta is the front matter of _project.md
+type projectMeta struct {
+	ID        int64      `yaml:"id"`
+	UUID      string     `yaml:"uuid,omitempty"`
+	Title     string     `yaml:"title"`
+	DeletedAt *time.Time `yaml:"deleted_at,omitempty"`
+	Link      linkMeta   `yaml:",inline"`
+}
+
+// taskMeta is the front matter of a task file. The note is the Markdown content after it.
+type taskMeta struct {
+	ID          int64      `yaml:"id"`
+	UUID        string     `yaml:"uuid,omitempty"`
+	Title       string     `yaml:"title"`
+	Due         string     `yaml:"due,omitempty"`
+	DueTime     string     `yaml:"due_time,omitempty"`
+	Start       string     `yaml:"start,omitempty"`
+	TimeZone    string     `yaml:"time_zone,omitempty"`
+	Remind      []string   `yaml:"remind,omitempty,flow"`
+	Completed   bool       `yaml:"completed"`
+	Status      string     `yaml:"status,omitempty"`
+	Remote      string     `yaml:"remote_status,omitempty"`
+	CompletedAt *time.Time `yaml:"completed_at,omitempty"`
+	Priority    string     `yaml:"priority,omitempty"`
+	Tags        []string   `yaml:"tags,omitempty,flow"`
+	Pinned      bool       `yaml:"pinned,omitempty"`
+	Order       int64      `yaml:"order,omitempty"`
+	Link        linkMeta   `yaml:",inline"`
+	CreatedAt   *time.Time `yaml:"created_at,omitempty"`
+	UpdatedAt   *time.Time `yaml:"updated_at,omitempty"`
+	DeletedAt   *time.Time `yaml:"deleted_at,omitempty"`
+}
+
+// snapshot is the content of the store, with the file of every record
+type snapshot struct {
+	projects    []model.Project
+	tasks       []model.Task
+	projectDirs map[int64]string
+	taskFiles   map[int64]string
+	lastProject int64 // Highest project ID
+	lastTask    int64 // Highest task ID
+}
+
+func (snap *snapshot) nextProjectID() int64 {
+	snap.lastProject++
+	return snap.lastProject
+}
+
+func (snap *snapshot) nextTaskID() int64 {
+	snap.lastTask++
+	return snap.lastTask
+}
+
+func (snap *snapshot) project(id int64) (model.Project, bool) {
+	for _, project := range snap.projects {
+		if project.ID == id {
+			return project, true
+		}
+	}
+
+	return model.Project{}, false
+}
+
+// projectDir is a project directory as read from disk
+type projectDir struct {
+	dir     string
+	project model.Project
+	tasks   []taskFile
+}
+
+type taskFile struct {
+	path string
+	task model.Task
+}
+
+// load reads all projects and tasks. Records without ID or UUID (e.g. created in an editor) get them saved.
+func (s *Store) load() (*snapshot, error) {
+	dirs, err := s.readDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &snapshot{projectDirs: make(map[int64]string), taskFiles: make(map[int64]string)}
+	for _, pd := range dirs {
+		snap.lastProject = maxID(snap.lastProject, pd.project.ID)
+		for _, tf := range pd.tasks {
+			snap.lastTask = maxID(snap.lastTask, tf.task.ID)
+		}
+	}
+
+	// Copied files have the same ID as their original, they get a new one
+	identify := func(id *int64, uuid *string, used map[int64]bool, nextID func() int64) bool {
+		changed := false
+		if *id == 0 || used[*id] {
+			*id, changed = nextID(), true
+		}
+		if *uuid == "" {
+			*uuid, changed = util.NewUUID(), true
+		}
+		used[*id] = true
+		return changed
+	}
+
+	usedProjectIDs, usedTaskIDs := make(map[int64]bool), make(map[int64]bool)
+	for _, pd := range dirs {
+		project := pd.project
+		if identify(&project.ID, &project.UUID, usedProjectIDs, snap.nextProjectID) {
+			if err := writeFile(filepath.Join(pd.dir, projectFile), encodeProject(project)); err != nil {
+				return nil, err
+			}
+		}
+		snap.projects = append(snap.projects, project)
+		snap.projectDirs[project.ID] = pd.dir
+
+		for _, tf := range pd.tasks {
+			task := tf.task
+			task.ProjectID = project.ID
+			if identify(&task.ID, &task.UUID, usedTaskIDs, snap.nextTaskID) {
+				if err := writeFile(tf.path, encodeTask(task)); err != nil {
+					return nil, err
+				}
+			}
+			snap.tasks = append(snap.tasks, task)
+			snap.taskFiles[task.ID] = tf.path
+		}
+	}
+
+	sort.Slice(snap.projects, func(i, j int) bool { return snap.projects[i].ID < snap.projects[j].ID })
+	sort.Slice(snap.tasks, func(i, j int) bool { return snap.tasks[i].ID < snap.tasks[j].ID })
+	return snap, nil
+}
+
+// readDirs reads project directories, with their tasks.
+// Directories without _project.md are projects too, if they have task files.
+func (s *Store) readDirs() ([]projectDir, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []projectDir
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		dir := filepath.Join(s.dir, entry.Name())
+		project, err := readProject(dir)
+		if err != nil {
+			return nil, err
+		}
+		tasks, err := readTasks(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		if project == nil {
+			if len(tasks) == 0 {
+				continue
+			}
+			project = &model.Project{Title: entry.Name()}
+		}
+		dirs = append(dirs, projectDir{dir: dir, project: *project, tasks: tasks})
+	}
+
+	return dirs, nil
+}
+
+func maxID(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// readProject reads _project.md of dir, nil if not exists
+func readProject(dir string) (*model.Project, error) {
+	content, err := os.ReadFile(filepath.Join(dir, projectFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var meta projectMeta
+	description, err := splitFrontMatter(content, &meta)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Join(dir, projectFile), err)
+	}
+
+	project := model.Project{
+		ID:           meta.ID,
+		UUID:         meta.UUID,
+		Title:        meta.Title,
+		Description:  description,
+		DeletedAt:    meta.DeletedAt,
+		ExternalLink: meta.Link.toExternalLink(),
+	}
+	if project.Title == "" {
+		project.Title = filepath.Base(dir)
+	}
+
+	return &project, nil
+}
+
+// readTasks reads task files of a project directory, sorted by file name
+func readTasks(dir string) ([]taskFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []taskFile
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == projectFile || filepath.Ext(entry.Name()) != taskExt {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var meta taskMeta
+		note, err := splitFrontMatter(content, &meta)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		task := model.Task{
+			ID:           meta.ID,
+			UUID:         meta.UUID,
+			Title:        meta.Title,
+			Details:      note,
+			Completed:    meta.Completed,
+			Status:       meta.Status,
+			RemoteStatus: meta.Remote,
+			DueTime:      meta.DueTime,
+			TimeZone:     meta.TimeZone,
+			CompletedAt:  meta.CompletedAt,
+			Priority:     meta.Priority,
+			Tags:         meta.Tags,
+			Pinned:       meta.Pinned,
+			Order:        meta.Order,
+			DeletedAt:    meta.DeletedAt,
+			ExternalLink: meta.Link.toExternalLink(),
+		}
+		if task.Title == "" {
+			task.Title = strings.TrimSuffix(entry.Name(), taskExt)
+		}
+		if meta.Due != "" {
+			due, err := time.ParseInLocation(dateLayout, meta.Due, task.Location())
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid due date %q", path, meta.Due)
+			}
+			task.DueDate = due.Unix()
+		}
+		if meta.Start != "" {
+			start, err := time.ParseInLocation(dateLayout, meta.Start, task.Location())
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid start date %q", path, meta.Start)
+			}
+			task.StartDate = start.Unix()
+		}
+		for _, text := range meta.Remind {
+			reminder, err := model.ParseReminder(text)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			task.Reminders = append(task.Reminders, reminder)
+		}
+		if meta.CreatedAt != nil {
+			task.CreatedAt = *meta.CreatedAt
+		}
+		if meta.UpdatedAt != nil {
+			task.UpdatedAt = *meta.UpdatedAt
+		}
+
+		tasks = append(tasks, taskFile{path: path, task: task})
+	}
+
+	return tasks, nil
+}
+
+// splitFrontMatter decodes YAML front matter into meta, and returns the content after it.
+// Content without front matter is returned as it is.
+func splitFrontMatter(content []byte, meta interface{}) (string, error) {
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	if !strings.HasPrefix(text, frontMatterLine+"\n") {
+		return text, nil
+	}
+
+	rest := text[len(frontMatterLine)+1:]
+	end := strings.Index(rest, "\n"+frontMatterLine+"\n")
+	body := ""
+	if end >= 0 {
+		body = rest[end+len(frontMatterLine)+2:]
+	} else if strings.HasSuffix(rest, "\n"+frontMatterLine) {
+		end = len(rest) - len(frontMatterLine) - 1
+	} else {
+		return "", fmt.Errorf("front matter is not closed with %s", frontMatterLine)
+	}
+
+	if err := yaml.Unmarshal([]byte(rest[:end]), meta); err != nil {
+		return "", fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	// A blank line separates front matter and content
+	return strings.TrimPrefix(body, "\n"), nil
+}
+
+func joinFrontMatter(meta interface{}, body string) []byte {
+	var content bytes.Buffer
+	encoded, err := yaml.Marshal(meta)
+	if err != nil {
+		// Only plain values are in meta
+		panic(err)
+	}
+
+	content.WriteString(frontMatterLine + "\n")
+	content.Write(encoded)
+	content.WriteString(frontMatterLine + "\n")
+	if body != "" {
+		content.WriteString("\n" + body)
+	}
+
+	return content.Bytes()
+}
+
+func encodeProject(project model.Project) []byte {
+	return joinFrontMatter(projectMeta{
+		ID:        project.ID,
+		UUID:      project.UUID,
+		Title:     project.Title,
+		DeletedAt: truncate(project.DeletedAt),
+		Link:      newLinkMeta(project.ExternalLink),
+	}, project.Description)
+}
+
+func encodeTask(task model.Task) []byte {
+	meta := taskMeta{
+		ID:          task.ID,
+		UUID:        task.UUID,
+		Title:       task.Title,
+		Completed:   task.Completed,
+		Status:      task.Status,
+		Remote:      task.RemoteStatus,
+		DueTime:     task.DueTime,
+		TimeZone:    task.TimeZone,
+		CompletedAt: truncate(task.CompletedAt),
+		Priority:    task.Priority,
+		Tags:        task.Tags,
+		Pinned:      task.Pinned,
+		Order:       task.Order,
+		Link:        newLinkMeta(task.ExternalLink),
+		CreatedAt:   truncate(&task.CreatedAt),
+		UpdatedAt:   truncate(&task.UpdatedAt),
+		DeletedAt:   truncate(task.DeletedAt),
+	}
+	if task.DueDate != 0 {
+		meta.Due = task.DueDay(task.Location()).Format(dateLayout)
+	}
+	if task.StartDate != 0 {
+		meta.Start = task.StartDay(task.Location()).Format(dateLayout)
+	}
+	for _, reminder := range task.Reminders {
+		meta.Remind = append(meta.Remind, reminder.String())
+	}
+
+	return joinFrontMatter(meta, task.Details)
+}
+
+func newLinkMeta(link model.ExternalLink) linkMeta {
+	return linkMeta{
+		Provider:        link.Provider,
+		Ticket:          link.ExternalKey,
+		TicketID:        link.ExternalID,
+		TicketURL:       link.ExternalURL,
+		RemoteCreatedAt: truncate(link.RemoteCreatedAt),
+		RemoteUpdatedAt: truncate(link.RemoteUpdatedAt),
+	}
+}
+
+func (meta linkMeta) toExternalLink() model.ExternalLink {
+	return model.ExternalLink{
+		Provider:        meta.Provider,
+		ExternalKey:     meta.Ticket,
+		ExternalID:      meta.TicketID,
+		ExternalURL:     meta.TicketURL,
+		RemoteCreatedAt: meta.RemoteCreatedAt,
+		RemoteUpdatedAt: meta.RemoteUpdatedAt,
+	}
+}
+
+// truncate drops fractions of second, to keep files readable. Zero time is left out.
+func truncate(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+
+	truncated := t.Truncate(time.Second)
+	return &truncated
+}
+
+// sameTime compares times as stored in files
+func sameTime(a, b time.Time) bool {
+	return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+}
+
+// writeFile replaces a file through a temporary file, so that it's never left half written
+func writeFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// slug makes a file name of title, e.g. "Paint the fence!" becomes paint-the-fence
+func slug(title string) string {
+	name := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(name) > maxSlugLength {
+		name = strings.TrimRight(name[:maxSlugLength], "-")
+	}
+	if name == "" {
+		name = "untitled"
+	}
+
+	return name
+}
+
+// freePath finds a path for a record named after its title. The ID is appended if the name is taken by another file.
+func freePath(current, dir, title string, id int64, ext string) string {
+	path := filepath.Join(dir, slug(title)+ext)
+	if path == current {
+		return path
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return path
+	}
+
+	return filepath.Join(dir, slug(title)+"-"+strconv.FormatInt(id, 10)+ext)
+}
+
+// saveProject writes _project.md, renaming the project directory when title has changed
+func (s *Store) saveProject(snap *snapshot, project model.Project) error {
+	current := snap.projectDirs[project.ID]
+	dir := freePath(current, s.dir, project.Title, project.ID, "")
+	if current != "" && current != dir {
+		if err := os.Rename(current, dir); err != nil {
+			return err
+		}
+		for id, path := range snap.taskFiles {
+			if filepath.Dir(path) == current {
+				snap.taskFiles[id] = filepath.Join(dir, filepath.Base(path))
+			}
+		}
+	}
+
+	if err := writeFile(filepath.Join(dir, projectFile), encodeProject(project)); err != nil {
+		return err
+	}
+
+	snap.projectDirs[project.ID] = dir
+	for i := range snap.projects {
+		if snap.projects[i].ID == project.ID {
+			snap.projects[i] = project
+			return nil
+		}
+	}
+	snap.projects = append(snap.projects, project)
+	return nil
+}
+
+// deleteProject removes _project.md, and the directory if nothing else is left in it
+func (s *Store) deleteProject(snap *snapshot, project model.Project) error {
+	dir, ok := snap.projectDirs[project.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if err := os.Remove(filepath.Join(dir, projectFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	_ = os.Remove(dir)
+
+	delete(snap.projectDirs, project.ID)
+	return nil
+}
+
+// saveTask writes a task file in the directory of its project, renaming (or moving) it when title or project has changed
+func (s *Store) saveTask(snap *snapshot, task model.Task) error {
+	dir, ok := snap.projectDirs[task.ProjectID]
+	if !ok {
+		return fmt.Errorf("project %d of task %q not found", task.ProjectID, task.Title)
+	}
+
+	current := snap.taskFiles[task.ID]
+	path := freePath(current, dir, task.Title, task.ID, taskExt)
+	if err := writeFile(path, encodeTask(task)); err != nil {
+		return err
+	}
+	if current != "" && current != path {
+		if err := os.Remove(current); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	snap.taskFiles[task.ID] = path
+	for i := range snap.tasks {
+		if snap.tasks[i].ID == task.ID {
+			snap.tasks[i] = task
+			return nil
+		}
+	}
+	snap.tasks = append(snap.tasks, task)
+	return nil
+}
+
+func (s *Store) deleteTask(snap *snapshot, task model.Task) error {
+	path, ok := snap.taskFiles[task.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	delete(snap.taskFiles, task.ID)
+	return nil
+}
+
+// appendHistory adds activities to the history file
+func (s *Store) appendHistory(activities []model.Activity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+
+	file, err := os.OpenFile(filepath.Join(s.dir, historyFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, activity := range activities {
+		if err := encoder.Encode(activity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) readHistory() ([]model.Activity, error) {
+	file, err := os.Open(filepath.Join(s.dir, historyFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var activities []model.Activity
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var activity model.Activity
+		// Lines broken by merges are skipped, rather than losing the whole history
+		if err := json.Unmarshal(scanner.Bytes(), &activity); err == nil {
+			activities = append(activities, activity)
+		}
+	}
+
+	return activities, scanner.Err()
+}
+
+// WriteAll writes records of another storage as they are, keeping their IDs. The store must be empty.
+func (s *Store) WriteAll(projects []model.Project, tasks []model.Task, activities []model.Activity) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap, err := s.load()
+	if err != nil {
+		return err
+	}
+	if len(snap.projects) > 0 {
+		return fmt.Errorf("%s already has projects", s.dir)
+	}
+
+	for _, project := range projects {
+		if err := s.saveProject(snap, project); err != nil {
+			return err
+		}
+	}
+	for _, task := range tasks {
+		if err := s.saveTask(snap, task); err != nil {
+			return err
+		}
+	}
+
+	return s.appendHistory(activities)
+}
@@ -0,0 +1,318 @@
+package markdown
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/repository"
+)
+
+type taskRepository struct {
+	store  *Store
+	source string // Recorded as source of the changes in task history
+}
+
+// NewTaskRepository will create an object that represent the repository.Task interface.
+// Changes are recorded as made from the TUI, use WithSource for other sources.
+func NewTaskRepository(store *Store) repository.TaskRepository {
+	return &taskRepository{store, model.ActivitySourceTUI}
+}
+
+func (t *taskRepository) WithSource(source string) repository.TaskRepository {
+	return &taskRepository{t.store, source}
+}
+
+func (t *taskRepository) GetAll() ([]model.Task, error) {
+	return t.find(func(task model.Task) bool { return !task.IsDeleted() })
+}
+
+func (t *taskRepository) GetAllDeleted() ([]model.Task, error) {
+	return t.find(func(task model.Task) bool { return task.IsDeleted() })
+}
+
+func (t *taskRepository) GetAllByProject(project model.Project) ([]model.Task, error) {
+	tasks, err := t.find(func(task model.Task) bool { return !task.IsDeleted() && task.ProjectID == project.ID })
+	repository.SortTasks(tasks)
+	return tasks, err
+}
+
+func (t *taskRepository) GetAllByDate(date time.Time) ([]model.Task, error) {
+	if date.IsZero() {
+		return t.find(func(task model.Task) bool { return !task.IsDeleted() && task.DueDate == 0 })
+	}
+
+	return t.GetAllByDateRange(date, date)
+}
+
+func (t *taskRepository) GetAllByDateRange(from, to time.Time) ([]model.Task, error) {
+	return t.find(func(task model.Task) bool {
+		// Unscheduled tasks are not in any range
+		return !task.IsDeleted() && repository.IsDayBetween(task.DueDay(to.Location()), from, to)
+	})
+}
+
+func (t *taskRepository) GetAllByStartDateRange(from, to time.Time) ([]model.Task, error) {
+	return t.find(func(task model.Task) bool {
+		return !task.IsDeleted() && repository.IsDayBetween(task.StartDay(to.Location()), from, to)
+	})
+}
+
+func (t *taskRepository) GetByID(ID string) (model.Task, error) {
+	id, err := strconv.ParseInt(ID, 10, 64)
+	if err != nil {
+		return model.Task{}, repository.ErrNotFound
+	}
+
+	return t.findOne(func(task model.Task) bool { return task.ID == id })
+}
+
+func (t *taskRepository) GetByUUID(UUID string) (model.Task, error) {
+	return t.findOne(func(task model.Task) bool { return task.UUID == UUID })
+}
+
+func (t *taskRepository) GetByExternalKey(provider, key string) (*model.Task, error) {
+	task, err := t.findOne(func(task model.Task) bool { return task.ExternalKey == key && task.Provider == provider })
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+func (t *taskRepository) Create(
+	project model.Project,
+	title, details, UUID string,
+	dueDate int64,
+) (model.Task, error) {
+	task := model.Task{
+		ProjectID: project.ID,
+		Title:     title,
+		Details:   details,
+		UUID:      UUID,
+		DueDate:   dueDate,
+	}
+
+	err := t.CreateTask(&task)
+	return task, err
+}
+
+func (t *taskRepository) CreateTask(task *model.Task) error {
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = time.Now()
+	}
+	repository.StampCompletion(nil, task)
+	repository.StampTimeZone(task)
+
+	return t.change(func(snap *snapshot) ([]model.Activity, error) {
+		task.ID = snap.nextTaskID()
+		if task.Order == 0 {
+			var siblings []model.Task
+			for _, other := range snap.tasks {
+				if other.ProjectID == task.ProjectID {
+					siblings = append(siblings, other)
+				}
+			}
+			task.Order = repository.NextOrder(siblings)
+		}
+		return []model.Activity{{Action: model.ActivityCreated, NewValue: task.Title}}, t.save(snap, task)
+	}, task)
+}
+
+func (t *taskRepository) Update(task *model.Task) error {
+	return t.change(func(snap *snapshot) ([]model.Activity, error) {
+		stored, err := findTask(snap, task.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		repository.StampCompletion(&stored, task)
+		repository.StampTimeZone(task)
+		return repository.DiffTask(stored, *task), t.save(snap, task)
+	}, task)
+}
+
+func (t *taskRepository) UpdateField(task *model.Task, field string, value interface{}) error {
+	return t.change(func(snap *snapshot) ([]model.Activity, error) {
+		stored, err := findTask(snap, task.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		updated := stored
+		fieldValue := reflect.ValueOf(&updated).Elem().FieldByName(field)
+		if !fieldValue.IsValid() || reflect.ValueOf(value).Kind() != fieldValue.Kind() {
+			return nil, repository.ErrNotFound
+		}
+		fieldValue.Set(reflect.ValueOf(value))
+
+		repository.StampCompletion(&stored, &updated)
+		repository.StampTimeZone(&updated)
+		if err := t.save(snap, &updated); err != nil {
+			return nil, err
+		}
+
+		*task = updated
+		return repository.DiffTask(stored, updated), nil
+	}, task)
+}
+
+func (t *taskRepository) SoftDelete(task *model.Task, at time.Time) error {
+	return t.change(func(snap *snapshot) ([]model.Activity, error) {
+		task.DeletedAt = &at
+		return []model.Activity{{Action: model.ActivityDeleted}}, t.save(snap, task)
+	}, task)
+}
+
+func (t *taskRepository) SoftDeleteAllByProjectID(projectID int64, at time.Time) error {
+	tasks, err := t.find(func(task model.Task) bool { return task.ProjectID == projectID && !task.IsDeleted() })
+	if err != nil {
+		return err
+	}
+
+	// Tasks already in Trash keep their own deletion time, so they are not restored along with the project
+	for i := range tasks {
+		if err := t.SoftDelete(&tasks[i], at); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *taskRepository) Restore(task *model.Task) error {
+	return t.change(func(snap *snapshot) ([]model.Activity, error) {
+		task.DeletedAt = nil
+		return []model.Activity{{Action: model.ActivityRestored}}, t.save(snap, task)
+	}, task)
+}
+
+func (t *taskRepository) RestoreAllByProjectID(projectID int64, deletedAt time.Time) error {
+	tasks, err := t.find(func(task model.Task) bool {
+		return task.ProjectID == projectID && task.IsDeleted() && sameTime(*task.DeletedAt, deletedAt)
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		if err := t.Restore(&tasks[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *taskRepository) Delete(task *model.Task) error {
+	return t.change(func(snap *snapshot) ([]model.Activity, error) {
+		return []model.Activity{{Action: model.ActivityPurged, OldValue: task.Title}}, t.store.deleteTask(snap, *task)
+	}, task)
+}
+
+func (t *taskRepository) DeleteAllByProjectID(projectID int64) error {
+	tasks, err := t.find(func(task model.Task) bool { return task.ProjectID == projectID })
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		if err := t.Delete(&tasks[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *taskRepository) SearchTasks(query string) ([]model.Task, error) {
+	return t.find(func(task model.Task) bool { return !task.IsDeleted() && matchesTask(task, query) })
+}
+
+func (t *taskRepository) SearchTasksInProject(projectID int64, query string) ([]model.Task, error) {
+	return t.find(func(task model.Task) bool {
+		return !task.IsDeleted() && task.ProjectID == projectID && matchesTask(task, query)
+	})
+}
+
+// find lists the tasks accepted by match, ordered by ID
+func (t *taskRepository) find(match func(task model.Task) bool) ([]model.Task, error) {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	snap, err := t.store.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []model.Task
+	for _, task := range snap.tasks {
+		if match(task) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, nil
+}
+
+func (t *taskRepository) findOne(match func(task model.Task) bool) (model.Task, error) {
+	tasks, err := t.find(match)
+	if err != nil {
+		return model.Task{}, err
+	}
+	if len(tasks) == 0 {
+		return model.Task{}, repository.ErrNotFound
+	}
+
+	return tasks[0], nil
+}
+
+// change runs apply on fresh content of the store and records the activities it returns in task history
+func (t *taskRepository) change(apply func(snap *snapshot) ([]model.Activity, error), task *model.Task) error {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	snap, err := t.store.load()
+	if err != nil {
+		return err
+	}
+
+	activities, err := apply(snap)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range activities {
+		activities[i].TaskID = task.ID
+		activities[i].Source = t.source
+		activities[i].CreatedAt = now
+	}
+	return t.store.appendHistory(activities)
+}
+
+func (t *taskRepository) save(snap *snapshot, task *model.Task) error {
+	task.UpdatedAt = time.Now()
+	repository.StampUUID(&task.UUID)
+	return t.store.saveTask(snap, *task)
+}
+
+func findTask(snap *snapshot, id int64) (model.Task, error) {
+	for _, task := range snap.tasks {
+		if task.ID == id {
+			return task, nil
+		}
+	}
+
+	return model.Task{}, repository.ErrNotFound
+}
+
+// matchesTask checks if query is in title, details or ticket key of task, ignoring case
+func matchesTask(task model.Task, query string) bool {
+	query = strings.ToLower(query)
+	return strings.Contains(strings.ToLower(task.Title), query) ||
+		strings.Contains(strings.ToLower(task.Details), query) ||
+		strings.Contains(strings.ToLower(task.ExternalKey), query)
+}
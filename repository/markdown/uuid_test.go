@@ -0,0 +1,16 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/ajaxray/geek-life/repository/repotest"
+)
+
+func TestCreateAssignsUUID(t *testing.T) {
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repotest.CreateAssignsUUID(t, NewProjectRepository(store), NewTaskRepository(store))
+}
@@ -1,22 +1,55 @@
 package repository
 
 import (
+	"sort"
 	"time"
+
 	"github.com/ajaxray/geek-life/model"
 )
 
-// ProjectRepository interface defines methods of project data accessor
+// ProjectRepository interface defines methods of project data accessor.
+// Listing and searching methods leave out projects in Trash.
+// Changes are recorded as model.Activity, attributed to the source set by WithSource.
 type ProjectRepository interface {
+	WithSource(source string) ProjectRepository
 	GetAll() ([]model.Project, error)
-	GetAllSortedByJiraDate() ([]model.Project, error)
+	GetAllDeleted() ([]model.Project, error)
+	GetAllSortedByRemoteDate() ([]model.Project, error)
 	GetByID(id int64) (model.Project, error)
 	GetByTitle(title string) (model.Project, error)
 	GetByUUID(UUID string) (model.Project, error)
 	Create(title, UUID string) (model.Project, error)
-	CreateWithJira(title, jiraID string) (model.Project, error)
-	CreateWithJiraAndDate(title, jiraID string, createdDate *time.Time) (model.Project, error)
+	CreateWithLink(title string, link model.ExternalLink) (model.Project, error)
 	Update(p *model.Project) error
 	UpdateField(p *model.Project, field string, value interface{}) error
+	SoftDelete(p *model.Project, at time.Time) error
+	Restore(p *model.Project) error
 	Delete(p *model.Project) error
 	SearchProjects(query string) ([]model.Project, error)
 }
+
+// SortProjectsByRemoteDate orders linked projects by remote creation date (newest first), then unlinked projects by title
+func SortProjectsByRemoteDate(projects []model.Project) {
+	sort.Slice(projects, func(i, j int) bool {
+		projectI := projects[i]
+		projectJ := projects[j]
+
+		// Both have remote creation dates - sort by date (newest first)
+		if projectI.RemoteCreatedAt != nil && projectJ.RemoteCreatedAt != nil {
+			return projectI.RemoteCreatedAt.After(*projectJ.RemoteCreatedAt)
+		}
+
+		// Only projectI has remote date - it comes first
+		if projectI.RemoteCreatedAt != nil && projectJ.RemoteCreatedAt == nil {
+			return true
+		}
+
+		// Only projectJ has remote date - it comes first
+		if projectI.RemoteCreatedAt == nil && projectJ.RemoteCreatedAt != nil {
+			return false
+		}
+
+		// Neither has remote date - sort by title alphabetically
+		return projectI.Title < projectJ.Title
+	})
+}
@@ -0,0 +1,61 @@
+// Package repotest has the test cases every storage backend of the repository interfaces must pass
+package repotest
+
+import (
+	"testing"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/repository"
+)
+
+const givenUUID = "0b6f0f3e-2a5c-4f4e-9b1a-3c2d1e0f9a8b"
+
+// CreateAssignsUUID checks that every way of creating projects and tasks gives them a UUID, keeping the given one
+func CreateAssignsUUID(t *testing.T, projectRepo repository.ProjectRepository, taskRepo repository.TaskRepository) {
+	t.Helper()
+
+	project, err := projectRepo.Create("Plain", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		create func() (string, error)
+		want   string // Empty for any new UUID
+	}{
+		{"Create", func() (string, error) {
+			return project.UUID, nil
+		}, ""},
+		{"CreateWithLink", func() (string, error) {
+			linked, err := projectRepo.CreateWithLink("Linked", model.ExternalLink{Provider: "jira", ExternalKey: "ENG-1"})
+			return linked.UUID, err
+		}, ""},
+		{"Create task", func() (string, error) {
+			task, err := taskRepo.Create(project, "Task", "", "", 0)
+			return task.UUID, err
+		}, ""},
+		{"CreateTask", func() (string, error) {
+			task := model.Task{ProjectID: project.ID, Title: "Imported"}
+			err := taskRepo.CreateTask(&task)
+			return task.UUID, err
+		}, ""},
+		{"CreateTask with UUID", func() (string, error) {
+			task := model.Task{ProjectID: project.ID, Title: "Synced", UUID: givenUUID}
+			err := taskRepo.CreateTask(&task)
+			return task.UUID, err
+		}, givenUUID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uuid, err := tt.create()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if uuid == "" || (tt.want != "" && uuid != tt.want) {
+				t.Errorf("UUID = %q, want %q", uuid, tt.want)
+			}
+		})
+	}
+}
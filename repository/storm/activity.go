@@ -0,0 +1,49 @@
+package storm
+
+import (
+	"sort"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/repository"
+)
+
+type activityRepository struct {
+	DB *storm.DB
+}
+
+// NewActivityRepository will create an object that represent the repository.Activity interface
+func NewActivityRepository(db *storm.DB) repository.ActivityRepository {
+	return &activityRepository{db}
+}
+
+func (repo *activityRepository) GetAllByTask(taskID int64) ([]model.Activity, error) {
+	var activities []model.Activity
+	err := repo.DB.Find("TaskID", taskID, &activities)
+	if err == storm.ErrNotFound {
+		return activities, nil
+	}
+
+	sortActivities(activities)
+	return activities, err
+}
+
+func (repo *activityRepository) GetAllByDateRange(from, to time.Time) ([]model.Activity, error) {
+	var activities []model.Activity
+	err := repo.DB.Select(q.Gte("CreatedAt", from), q.Lte("CreatedAt", to)).Find(&activities)
+	if err == storm.ErrNotFound {
+		return activities, nil
+	}
+
+	sortActivities(activities)
+	return activities, err
+}
+
+func sortActivities(activities []model.Activity) {
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].CreatedAt.Before(activities[j].CreatedAt)
+	})
+}
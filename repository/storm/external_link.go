@@ -0,0 +1,114 @@
+package storm
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ajaxray/geek-life/model"
+)
+
+// legacyLink holds the JIRA specific fields used before model.ExternalLink.
+// Linear links were stored in the same fields.
+type legacyLink struct {
+	Jira            string     `json:"jira"`
+	JiraCreatedDate *time.Time `json:"jira_created_date"`
+	Provider        string     `json:"provider"`
+}
+
+// MigrateExternalLinks converts the JIRA specific fields of stored projects and tasks to model.ExternalLink.
+// Links without a recorded provider are assigned to defaultProvider. Already converted records are skipped,
+// so it's safe to run multiple times. Returns the number of converted records.
+func MigrateExternalLinks(db *storm.DB, defaultProvider string) (int, error) {
+	var projects []model.Project
+	projectLinks := make(map[int64]legacyLink)
+	err := eachRaw(db, "Project", func(raw []byte) error {
+		var project model.Project
+		var legacy legacyLink
+		if err := json.Unmarshal(raw, &project); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, &legacy); err != nil {
+			return err
+		}
+
+		projectLinks[project.ID] = legacy
+		if legacy.Jira != "" && !project.IsLinked() {
+			project.ExternalLink = model.ExternalLink{
+				Provider:        providerOrDefault(legacy.Provider, defaultProvider),
+				ExternalKey:     legacy.Jira,
+				RemoteCreatedAt: legacy.JiraCreatedDate,
+			}
+			projects = append(projects, project)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var tasks []model.Task
+	err = eachRaw(db, "Task", func(raw []byte) error {
+		var task model.Task
+		var legacy legacyLink
+		if err := json.Unmarshal(raw, &task); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, &legacy); err != nil {
+			return err
+		}
+
+		if legacy.Jira != "" && !task.IsLinked() {
+			// Tasks belong to the ticket system of their project
+			task.ExternalLink = model.ExternalLink{
+				Provider:    providerOrDefault(projectLinks[task.ProjectID].Provider, defaultProvider),
+				ExternalKey: legacy.Jira,
+			}
+			tasks = append(tasks, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range projects {
+		if err := db.Save(&projects[i]); err != nil {
+			return 0, err
+		}
+	}
+	for i := range tasks {
+		if err := db.Save(&tasks[i]); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(projects) + len(tasks), nil
+}
+
+// eachRaw calls fn with the stored (encoded) value of every record in a storm bucket
+func eachRaw(db *storm.DB, bucketName string, fn func(raw []byte) error) error {
+	return db.Bolt.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketName))
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(_, v []byte) error {
+			// Nested buckets (indexes, metadata) have nil value
+			if v == nil {
+				return nil
+			}
+			return fn(v)
+		})
+	})
+}
+
+func providerOrDefault(provider, defaultProvider string) string {
+	if provider == "" {
+		return defaultProvider
+	}
+	return provider
+}
@@ -1,7 +1,7 @@
 package storm
 
 import (
-	"sort"
+	"reflect"
 	"strings"
 	"time"
 
@@ -12,52 +12,41 @@ import (
 )
 
 type projectRepository struct {
-	DB *storm.DB
+	DB     *storm.DB
+	source string // Recorded as source of the changes in project history
 }
 
-// NewProjectRepository will create an object that represent the repository.Project interface
+// NewProjectRepository will create an object that represent the repository.Project interface.
+// Changes are recorded as made from the TUI, use WithSource for other sources.
 func NewProjectRepository(db *storm.DB) repository.ProjectRepository {
-	return &projectRepository{db}
+	return &projectRepository{db, model.ActivitySourceTUI}
+}
+
+func (repo *projectRepository) WithSource(source string) repository.ProjectRepository {
+	return &projectRepository{repo.DB, source}
 }
 
 func (repo *projectRepository) GetAll() ([]model.Project, error) {
 	var projects []model.Project
 	err := repo.DB.All(&projects)
 
-	return projects, err
+	return filterProjects(projects, false), err
 }
 
-func (repo *projectRepository) GetAllSortedByJiraDate() ([]model.Project, error) {
+func (repo *projectRepository) GetAllDeleted() ([]model.Project, error) {
 	var projects []model.Project
 	err := repo.DB.All(&projects)
+
+	return filterProjects(projects, true), err
+}
+
+func (repo *projectRepository) GetAllSortedByRemoteDate() ([]model.Project, error) {
+	projects, err := repo.GetAll()
 	if err != nil {
 		return projects, err
 	}
 
-	// Sort projects: JIRA projects by creation date (newest first), then non-JIRA projects
-	sort.Slice(projects, func(i, j int) bool {
-		projectI := projects[i]
-		projectJ := projects[j]
-
-		// Both have JIRA creation dates - sort by date (newest first)
-		if projectI.JiraCreatedDate != nil && projectJ.JiraCreatedDate != nil {
-			return projectI.JiraCreatedDate.After(*projectJ.JiraCreatedDate)
-		}
-
-		// Only projectI has JIRA date - it comes first
-		if projectI.JiraCreatedDate != nil && projectJ.JiraCreatedDate == nil {
-			return true
-		}
-
-		// Only projectJ has JIRA date - it comes first
-		if projectI.JiraCreatedDate == nil && projectJ.JiraCreatedDate != nil {
-			return false
-		}
-
-		// Neither has JIRA date - sort by title alphabetically
-		return projectI.Title < projectJ.Title
-	})
-
+	repository.SortProjectsByRemoteDate(projects)
 	return projects, nil
 }
 
@@ -70,7 +59,7 @@ func (repo *projectRepository) GetByTitle(title string) (model.Project, error) {
 }
 
 func (repo *projectRepository) GetByUUID(UUID string) (model.Project, error) {
-	return repo.getOneByField("CloudId", UUID)
+	return repo.getOneByField("UUID", UUID)
 }
 
 func (repo *projectRepository) Create(title, UUID string) (model.Project, error) {
@@ -79,50 +68,86 @@ func (repo *projectRepository) Create(title, UUID string) (model.Project, error)
 		UUID:  UUID,
 	}
 
-	err := repo.DB.Save(&project)
+	err := repo.save(&project, model.Activity{Action: model.ActivityCreated, NewValue: title})
 	return project, err
 }
 
-func (repo *projectRepository) CreateWithJira(title, jiraID string) (model.Project, error) {
+func (repo *projectRepository) CreateWithLink(title string, link model.ExternalLink) (model.Project, error) {
 	project := model.Project{
-		Title: title,
-		Jira:  jiraID,
+		Title:        title,
+		ExternalLink: link,
 	}
 
-	err := repo.DB.Save(&project)
+	err := repo.save(&project, model.Activity{Action: model.ActivityCreated, NewValue: title})
 	return project, err
 }
 
-func (repo *projectRepository) CreateWithJiraAndDate(title, jiraID string, createdDate *time.Time) (model.Project, error) {
-	project := model.Project{
-		Title:           title,
-		Jira:            jiraID,
-		JiraCreatedDate: createdDate,
+func (repo *projectRepository) Update(project *model.Project) error {
+	var stored model.Project
+	if err := repo.DB.One("ID", project.ID, &stored); err != nil {
+		return err
 	}
 
-	err := repo.DB.Save(&project)
-	return project, err
+	return repo.save(project, repository.DiffProject(stored, *project)...)
 }
 
-func (repo *projectRepository) Update(project *model.Project) error {
-	return repo.DB.Save(project)
+func (repo *projectRepository) SoftDelete(project *model.Project, at time.Time) error {
+	project.DeletedAt = &at
+	return repo.save(project, model.Activity{Action: model.ActivityDeleted})
+}
+
+func (repo *projectRepository) Restore(project *model.Project) error {
+	project.DeletedAt = nil
+	return repo.save(project, model.Activity{Action: model.ActivityRestored})
 }
 
 func (repo *projectRepository) Delete(project *model.Project) error {
-	return repo.DB.DeleteStruct(project)
+	tx, err := repo.DB.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.DeleteStruct(project); err != nil {
+		return err
+	}
+	if err := repo.record(tx, project.ID, model.Activity{Action: model.ActivityPurged, OldValue: project.Title}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (repo *projectRepository) UpdateField(
-	task *model.Project,
+	project *model.Project,
 	field string,
 	value interface{},
 ) error {
-	return repo.DB.UpdateField(task, field, value)
+	var stored model.Project
+	if err := repo.DB.One("ID", project.ID, &stored); err != nil {
+		return err
+	}
+
+	updated := stored
+	fieldValue := reflect.ValueOf(&updated).Elem().FieldByName(field)
+	if !fieldValue.IsValid() {
+		return storm.ErrNotFound
+	}
+	if reflect.ValueOf(value).Kind() != fieldValue.Kind() {
+		return storm.ErrIncompatibleValue
+	}
+	fieldValue.Set(reflect.ValueOf(value))
+
+	if err := repo.save(&updated, repository.DiffProject(stored, updated)...); err != nil {
+		return err
+	}
+
+	*project = updated
+	return nil
 }
 
 func (repo *projectRepository) SearchProjects(query string) ([]model.Project, error) {
-	var allProjects []model.Project
-	err := repo.DB.All(&allProjects)
+	allProjects, err := repo.GetAll()
 	if err != nil {
 		return nil, err
 	}
@@ -131,9 +156,9 @@ func (repo *projectRepository) SearchProjects(query string) ([]model.Project, er
 	lowerQuery := strings.ToLower(query)
 
 	for _, project := range allProjects {
-		// Search in title and JIRA ID
+		// Search in title and ticket key
 		if strings.Contains(strings.ToLower(project.Title), lowerQuery) ||
-			strings.Contains(strings.ToLower(project.Jira), lowerQuery) {
+			strings.Contains(strings.ToLower(project.ExternalKey), lowerQuery) {
 			matchingProjects = append(matchingProjects, project)
 		}
 	}
@@ -150,3 +175,50 @@ func (repo *projectRepository) getOneByField(
 
 	return project, err
 }
+
+// filterProjects keeps the projects that are (deleted = true) or are not (deleted = false) in Trash
+func filterProjects(projects []model.Project, deleted bool) []model.Project {
+	var filtered []model.Project
+	for _, project := range projects {
+		if project.IsDeleted() == deleted {
+			filtered = append(filtered, project)
+		}
+	}
+
+	return filtered
+}
+
+func (repo *projectRepository) save(project *model.Project, activities ...model.Activity) error {
+	repository.StampUUID(&project.UUID)
+
+	tx, err := repo.DB.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.Save(project); err != nil {
+		return err
+	}
+	// ID of a new project is known only after saving
+	if err := repo.record(tx, project.ID, activities...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// record appends activities of a project to its history
+func (repo *projectRepository) record(tx storm.Node, projectID int64, activities ...model.Activity) error {
+	now := time.Now()
+	for i := range activities {
+		activities[i].ProjectID = projectID
+		activities[i].Source = repo.source
+		activities[i].CreatedAt = now
+		if err := tx.Save(&activities[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
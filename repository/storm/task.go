@@ -1,6 +1,8 @@
 package storm
 
 import (
+	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,16 +13,32 @@ import (
 )
 
 type taskRepository struct {
-	DB *storm.DB
+	DB     *storm.DB
+	source string // Recorded as source of the changes in task history
 }
 
-// NewTaskRepository will create an object that represent the repository.Task interface
+// NewTaskRepository will create an object that represent the repository.Task interface.
+// Changes are recorded as made from the TUI, use WithSource for other sources.
 func NewTaskRepository(db *storm.DB) repository.TaskRepository {
-	return &taskRepository{db}
+	return &taskRepository{db, model.ActivitySourceTUI}
+}
+
+func (t *taskRepository) WithSource(source string) repository.TaskRepository {
+	return &taskRepository{t.DB, source}
 }
 
 func (t *taskRepository) GetAll() ([]model.Task, error) {
-	panic("implement me")
+	var tasks []model.Task
+	err := t.DB.All(&tasks)
+
+	return filterTasks(tasks, false), err
+}
+
+func (t *taskRepository) GetAllDeleted() ([]model.Task, error) {
+	var tasks []model.Task
+	err := t.DB.All(&tasks)
+
+	return filterTasks(tasks, true), err
 }
 
 func (t *taskRepository) GetAllByProject(project model.Project) ([]model.Task, error) {
@@ -28,6 +46,8 @@ func (t *taskRepository) GetAllByProject(project model.Project) ([]model.Task, e
 	//err = db.Find("ProjetID", project.ID, &tasks, storm.Limit(10), storm.Skip(10), storm.Reverse())
 	err := t.DB.Find("ProjectID", project.ID, &tasks)
 
+	tasks = filterTasks(tasks, false)
+	repository.SortTasks(tasks)
 	return tasks, err
 }
 
@@ -43,35 +63,71 @@ func (t *taskRepository) GetAllByDate(date time.Time) ([]model.Task, error) {
 			}
 		}
 
-		return tasks, err
-	} else {
-		err := t.DB.Find("DueDate", getRoundedDueDate(date), &tasks)
-		return tasks, err
+		return filterTasks(tasks, false), err
 	}
+
+	return t.GetAllByDateRange(date, date)
 }
 
 func (t *taskRepository) GetAllByDateRange(from, to time.Time) ([]model.Task, error) {
-	var tasks []model.Task
+	return t.findByDay("DueDate", from, to, func(task model.Task) time.Time { return task.DueDay(to.Location()) })
+}
 
-	err := t.DB.Range("DueDate", getRoundedDueDate(from), getRoundedDueDate(to), &tasks)
-	return tasks, err
+func (t *taskRepository) GetAllByStartDateRange(from, to time.Time) ([]model.Task, error) {
+	return t.findByDay("StartDate", from, to, func(task model.Task) time.Time { return task.StartDay(to.Location()) })
+}
+
+// findByDay finds tasks with a day (DueDate or StartDate field) from `from` to `to`, see repository.DayRange
+func (t *taskRepository) findByDay(
+	field string,
+	from, to time.Time,
+	dayOf func(task model.Task) time.Time,
+) ([]model.Task, error) {
+	var tasks, found []model.Task
+
+	min, max := repository.DayRange(from, to)
+	err := t.DB.Range(field, min, max, &tasks)
+	for _, task := range filterTasks(tasks, false) {
+		if repository.IsDayBetween(dayOf(task), from, to) {
+			found = append(found, task)
+		}
+	}
+
+	return found, err
 }
 
 func (t *taskRepository) GetByID(ID string) (model.Task, error) {
-	panic("implement me")
+	var task model.Task
+	id, err := strconv.ParseInt(ID, 10, 64)
+	if err != nil {
+		return task, storm.ErrNotFound
+	}
+
+	err = t.DB.One("ID", id, &task)
+	return task, err
 }
 
 func (t *taskRepository) GetByUUID(UUID string) (model.Task, error) {
-	panic("implement me")
+	var task model.Task
+	err := t.DB.One("UUID", UUID, &task)
+
+	return task, err
 }
 
-func (t *taskRepository) GetByJiraID(jiraID string) (*model.Task, error) {
-	var task model.Task
-	err := t.DB.One("JiraID", jiraID, &task)
+func (t *taskRepository) GetByExternalKey(provider, key string) (*model.Task, error) {
+	var tasks []model.Task
+	err := t.DB.Find("ExternalKey", key, &tasks)
 	if err != nil {
 		return nil, err
 	}
-	return &task, nil
+
+	// Keys of different providers may collide (e.g. ENG-1 in both JIRA and Linear)
+	for i := range tasks {
+		if tasks[i].Provider == provider {
+			return &tasks[i], nil
+		}
+	}
+	return nil, storm.ErrNotFound
 }
 
 func (t *taskRepository) Create(
@@ -87,24 +143,129 @@ func (t *taskRepository) Create(
 		DueDate:   dueDate,
 	}
 
-	err := t.DB.Save(&task)
+	err := t.CreateTask(&task)
 	return task, err
 }
 
 func (t *taskRepository) CreateTask(task *model.Task) error {
-	return t.DB.Save(task)
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = time.Now()
+	}
+	repository.StampCompletion(nil, task)
+	repository.StampTimeZone(task)
+	if task.Order == 0 {
+		var siblings []model.Task
+		if err := t.DB.Find("ProjectID", task.ProjectID, &siblings); err != nil && err != storm.ErrNotFound {
+			return err
+		}
+		task.Order = repository.NextOrder(siblings)
+	}
+
+	return t.save(task, model.Activity{Action: model.ActivityCreated, NewValue: task.Title})
 }
 
 func (t *taskRepository) Update(task *model.Task) error {
-	return t.DB.Update(task)
+	var stored model.Task
+	if err := t.DB.One("ID", task.ID, &stored); err != nil {
+		return err
+	}
+
+	repository.StampCompletion(&stored, task)
+	repository.StampTimeZone(task)
+	return t.save(task, repository.DiffTask(stored, *task)...)
 }
 
 func (t *taskRepository) UpdateField(task *model.Task, field string, value interface{}) error {
-	return t.DB.UpdateField(task, field, value)
+	var stored model.Task
+	if err := t.DB.One("ID", task.ID, &stored); err != nil {
+		return err
+	}
+
+	updated := stored
+	fieldValue := reflect.ValueOf(&updated).Elem().FieldByName(field)
+	if !fieldValue.IsValid() {
+		return storm.ErrNotFound
+	}
+	if reflect.ValueOf(value).Kind() != fieldValue.Kind() {
+		return storm.ErrIncompatibleValue
+	}
+	fieldValue.Set(reflect.ValueOf(value))
+
+	repository.StampCompletion(&stored, &updated)
+	repository.StampTimeZone(&updated)
+	if err := t.save(&updated, repository.DiffTask(stored, updated)...); err != nil {
+		return err
+	}
+
+	*task = updated
+	return nil
+}
+
+func (t *taskRepository) SoftDelete(task *model.Task, at time.Time) error {
+	task.DeletedAt = &at
+	return t.save(task, model.Activity{Action: model.ActivityDeleted})
+}
+
+func (t *taskRepository) SoftDeleteAllByProjectID(projectID int64, at time.Time) error {
+	var tasks []model.Task
+	err := t.DB.Find("ProjectID", projectID, &tasks)
+	if err == storm.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// Tasks already in Trash keep their own deletion time, so they are not restored along with the project
+	tasks = filterTasks(tasks, false)
+	for i := range tasks {
+		if err := t.SoftDelete(&tasks[i], at); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *taskRepository) Restore(task *model.Task) error {
+	task.DeletedAt = nil
+	return t.save(task, model.Activity{Action: model.ActivityRestored})
+}
+
+func (t *taskRepository) RestoreAllByProjectID(projectID int64, deletedAt time.Time) error {
+	var tasks []model.Task
+	err := t.DB.Find("ProjectID", projectID, &tasks)
+	if err == storm.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		if tasks[i].IsDeleted() && tasks[i].DeletedAt.Equal(deletedAt) {
+			if err := t.Restore(&tasks[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
 func (t *taskRepository) Delete(task *model.Task) error {
-	return t.DB.DeleteStruct(task)
+	tx, err := t.DB.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.DeleteStruct(task); err != nil {
+		return err
+	}
+	if err := t.record(tx, task.ID, model.Activity{Action: model.ActivityPurged, OldValue: task.Title}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (t *taskRepository) DeleteAllByProjectID(projectID int64) error {
@@ -119,7 +280,7 @@ func (t *taskRepository) DeleteAllByProjectID(projectID int64) error {
 	}
 
 	for i := range tasks {
-		if err := t.DB.DeleteStruct(&tasks[i]); err != nil {
+		if err := t.Delete(&tasks[i]); err != nil {
 			return err
 		}
 	}
@@ -128,8 +289,7 @@ func (t *taskRepository) DeleteAllByProjectID(projectID int64) error {
 }
 
 func (t *taskRepository) SearchTasks(query string) ([]model.Task, error) {
-	var allTasks []model.Task
-	err := t.DB.All(&allTasks)
+	allTasks, err := t.GetAll()
 	if err != nil {
 		return nil, err
 	}
@@ -138,10 +298,10 @@ func (t *taskRepository) SearchTasks(query string) ([]model.Task, error) {
 	lowerQuery := strings.ToLower(query)
 
 	for _, task := range allTasks {
-		// Search in title, details, and JIRA ID
+		// Search in title, details, and ticket key
 		if strings.Contains(strings.ToLower(task.Title), lowerQuery) ||
 			strings.Contains(strings.ToLower(task.Details), lowerQuery) ||
-			strings.Contains(strings.ToLower(task.JiraID), lowerQuery) {
+			strings.Contains(strings.ToLower(task.ExternalKey), lowerQuery) {
 			matchingTasks = append(matchingTasks, task)
 		}
 	}
@@ -162,11 +322,11 @@ func (t *taskRepository) SearchTasksInProject(projectID int64, query string) ([]
 	var matchingTasks []model.Task
 	lowerQuery := strings.ToLower(query)
 
-	for _, task := range projectTasks {
-		// Search in title, details, and JIRA ID
+	for _, task := range filterTasks(projectTasks, false) {
+		// Search in title, details, and ticket key
 		if strings.Contains(strings.ToLower(task.Title), lowerQuery) ||
 			strings.Contains(strings.ToLower(task.Details), lowerQuery) ||
-			strings.Contains(strings.ToLower(task.JiraID), lowerQuery) {
+			strings.Contains(strings.ToLower(task.ExternalKey), lowerQuery) {
 			matchingTasks = append(matchingTasks, task)
 		}
 	}
@@ -174,10 +334,52 @@ func (t *taskRepository) SearchTasksInProject(projectID int64, query string) ([]
 	return matchingTasks, nil
 }
 
-func getRoundedDueDate(date time.Time) int64 {
-	if date.IsZero() {
-		return 0
+// save stores a task along with the activities of the change, in a single transaction.
+// The whole record is saved, as storm's Update skips zero values (e.g. unsetting Completed).
+func (t *taskRepository) save(task *model.Task, activities ...model.Activity) error {
+	task.UpdatedAt = time.Now()
+	repository.StampUUID(&task.UUID)
+
+	tx, err := t.DB.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.Save(task); err != nil {
+		return err
+	}
+	// ID of a new task is known only after saving
+	if err := t.record(tx, task.ID, activities...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// record appends activities of a task to its history
+func (t *taskRepository) record(tx storm.Node, taskID int64, activities ...model.Activity) error {
+	now := time.Now()
+	for i := range activities {
+		activities[i].TaskID = taskID
+		activities[i].Source = t.source
+		activities[i].CreatedAt = now
+		if err := tx.Save(&activities[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// filterTasks keeps the tasks that are (deleted = true) or are not (deleted = false) in Trash
+func filterTasks(tasks []model.Task, deleted bool) []model.Task {
+	var filtered []model.Task
+	for _, task := range tasks {
+		if task.IsDeleted() == deleted {
+			filtered = append(filtered, task)
+		}
 	}
 
-	return date.Unix()
+	return filtered
 }
@@ -0,0 +1,20 @@
+package storm
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/asdine/storm/v3"
+
+	"github.com/ajaxray/geek-life/repository/repotest"
+)
+
+func TestCreateAssignsUUID(t *testing.T) {
+	db, err := storm.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	repotest.CreateAssignsUUID(t, NewProjectRepository(db), NewTaskRepository(db))
+}
@@ -1,26 +1,96 @@
 package repository
 
 import (
+	"sort"
 	"time"
 
 	"github.com/ajaxray/geek-life/model"
 )
 
-// TaskRepository interface defines methods of task data accessor
+// TaskRepository interface defines methods of task data accessor.
+// Listing and searching methods leave out tasks in Trash.
+// Changes are recorded as model.Activity, attributed to the source set by WithSource.
 type TaskRepository interface {
+	WithSource(source string) TaskRepository
 	GetAll() ([]model.Task, error)
+	GetAllDeleted() ([]model.Task, error)
 	GetAllByProject(project model.Project) ([]model.Task, error)
 	GetAllByDate(date time.Time) ([]model.Task, error)
 	GetAllByDateRange(from, to time.Time) ([]model.Task, error)
+	GetAllByStartDateRange(from, to time.Time) ([]model.Task, error)
 	GetByID(ID string) (model.Task, error)
 	GetByUUID(UUID string) (model.Task, error)
-	GetByJiraID(jiraID string) (*model.Task, error)
+	GetByExternalKey(provider, key string) (*model.Task, error)
 	Create(project model.Project, title, details, UUID string, dueDate int64) (model.Task, error)
 	CreateTask(task *model.Task) error
 	Update(t *model.Task) error
 	UpdateField(t *model.Task, field string, value interface{}) error
+	SoftDelete(t *model.Task, at time.Time) error
+	SoftDeleteAllByProjectID(projectID int64, at time.Time) error
+	Restore(t *model.Task) error
+	RestoreAllByProjectID(projectID int64, deletedAt time.Time) error
 	Delete(t *model.Task) error
 	DeleteAllByProjectID(projectID int64) error
 	SearchTasks(query string) ([]model.Task, error)
 	SearchTasksInProject(projectID int64, query string) ([]model.Task, error)
 }
+
+// zoneSpread is the most the midnights of a date differ by time zone (UTC-12:00 to UTC+14:00)
+const zoneSpread = 26 * time.Hour
+
+// DayRange is the range of Unix times that days from `from` to `to` are kept as, in any time zone.
+// Zero from is the beginning of time. Tasks in the range are then filtered by IsDayBetween.
+func DayRange(from, to time.Time) (int64, int64) {
+	min := int64(1)
+	if !from.IsZero() {
+		min = from.Add(-zoneSpread).Unix()
+	}
+
+	return min, to.Add(zoneSpread).Unix()
+}
+
+// IsDayBetween checks if a day (e.g. of task.DueDay) is from `from` to `to`, both inclusive. Zero from is any day till `to`.
+func IsDayBetween(day, from, to time.Time) bool {
+	return !day.IsZero() && (from.IsZero() || !day.Before(from)) && !day.After(to)
+}
+
+// SortTasks orders pinned tasks first, then by project and manual order.
+// Tasks never reordered (zero Order) keep the order of creation.
+func SortTasks(tasks []model.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		taskI, taskJ := tasks[i], tasks[j]
+		if taskI.Pinned != taskJ.Pinned {
+			return taskI.Pinned
+		}
+		if taskI.ProjectID != taskJ.ProjectID {
+			return taskI.ProjectID < taskJ.ProjectID
+		}
+		if taskI.Order != taskJ.Order {
+			return taskI.Order < taskJ.Order
+		}
+		return taskI.ID < taskJ.ID
+	})
+}
+
+// SortByDue orders tasks by the moment they are due (see model.Task.DueAt), unscheduled ones last.
+// Tasks due at the same moment keep their order.
+func SortByDue(tasks []model.Task, loc *time.Location) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		dueI, dueJ := tasks[i].DueAt(loc), tasks[j].DueAt(loc)
+		if dueI.IsZero() != dueJ.IsZero() {
+			return dueJ.IsZero()
+		}
+		return dueI.Before(dueJ)
+	})
+}
+
+// NextOrder is the Order that puts a new task at the bottom of the project with tasks
+func NextOrder(tasks []model.Task) int64 {
+	var last int64
+	for _, task := range tasks {
+		if task.Order > last {
+			last = task.Order
+		}
+	}
+	return last + 1
+}
@@ -0,0 +1,204 @@
+// Package stats computes productivity statistics (created, completed and overdue tasks) for a date range
+package stats
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+)
+
+// Options selects the tasks to compute statistics on
+type Options struct {
+	From      time.Time // First day of the range
+	To        time.Time // Last day of the range (inclusive)
+	ProjectID int64     // Limit to a project. 0 for all projects.
+}
+
+// Period holds task counts of a day or a week
+type Period struct {
+	Start     time.Time `json:"start"`
+	Created   int       `json:"created"`
+	Completed int       `json:"completed"`
+}
+
+// ProjectStats holds task counts of a project
+type ProjectStats struct {
+	ProjectID int64  `json:"project_id"`
+	Title     string `json:"title"`
+	Created   int    `json:"created"`
+	Completed int    `json:"completed"`
+	Open      int    `json:"open"`
+	Overdue   int    `json:"overdue"`
+}
+
+// Report is the statistics of a date range
+type Report struct {
+	From                  time.Time      `json:"from"`
+	To                    time.Time      `json:"to"`
+	Created               int            `json:"created"`
+	Completed             int            `json:"completed"`
+	Overdue               int            `json:"overdue"`
+	AverageDaysToComplete float64        `json:"average_days_to_complete"`
+	Days                  []Period       `json:"days"`
+	Weeks                 []Period       `json:"weeks"`
+	Projects              []ProjectStats `json:"projects"`
+}
+
+// Compute makes a Report of tasks created and completed in the range of opts.
+// Overdue counts are of open tasks that were overdue at the end of range (or now, if earlier).
+// Tasks created before timestamps were recorded are counted only by completion and due date.
+func Compute(tasks []model.Task, projects []model.Project, opts Options, now time.Time) Report {
+	from, to := StartOfDay(opts.From), StartOfDay(opts.To).AddDate(0, 0, 1)
+	overdueAt := now
+	if to.Before(overdueAt) {
+		overdueAt = to
+	}
+
+	report := Report{From: from, To: to.AddDate(0, 0, -1)}
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		report.Days = append(report.Days, Period{Start: day})
+	}
+
+	projectStats := make(map[int64]*ProjectStats)
+	for _, project := range projects {
+		if opts.ProjectID == 0 || project.ID == opts.ProjectID {
+			projectStats[project.ID] = &ProjectStats{ProjectID: project.ID, Title: project.Title}
+		}
+	}
+
+	var timeToComplete time.Duration
+	var timedCompletions int
+	for _, task := range tasks {
+		ps, ok := projectStats[task.ProjectID]
+		if !ok {
+			continue
+		}
+
+		if inRange(task.CreatedAt, from, to) {
+			report.Created++
+			ps.Created++
+			report.Days[dayIndex(from, task.CreatedAt)].Created++
+		}
+
+		// Cancelled tasks are closed, but not counted as completed
+		if task.CompletedAt != nil && task.CurrentStatus() != model.StatusCancelled && inRange(*task.CompletedAt, from, to) {
+			report.Completed++
+			ps.Completed++
+			report.Days[dayIndex(from, *task.CompletedAt)].Completed++
+
+			if !task.CreatedAt.IsZero() && task.CompletedAt.After(task.CreatedAt) {
+				timeToComplete += task.CompletedAt.Sub(task.CreatedAt)
+				timedCompletions++
+			}
+		}
+
+		if !task.Completed {
+			ps.Open++
+			if task.IsOverdue(overdueAt) {
+				report.Overdue++
+				ps.Overdue++
+			}
+		}
+	}
+
+	if timedCompletions > 0 {
+		report.AverageDaysToComplete = timeToComplete.Hours() / 24 / float64(timedCompletions)
+	}
+	report.Weeks = groupByWeek(report.Days)
+
+	for _, ps := range projectStats {
+		if ps.Created+ps.Completed+ps.Open > 0 {
+			report.Projects = append(report.Projects, *ps)
+		}
+	}
+	sort.Slice(report.Projects, func(i, j int) bool {
+		if report.Projects[i].Completed != report.Projects[j].Completed {
+			return report.Projects[i].Completed > report.Projects[j].Completed
+		}
+		return report.Projects[i].Title < report.Projects[j].Title
+	})
+
+	return report
+}
+
+// CreatedPerDay lists number of created tasks per day, e.g. for Sparkline
+func (r Report) CreatedPerDay() []int {
+	values := make([]int, len(r.Days))
+	for i, day := range r.Days {
+		values[i] = day.Created
+	}
+	return values
+}
+
+// CompletedPerDay lists number of completed tasks per day, e.g. for Sparkline
+func (r Report) CompletedPerDay() []int {
+	values := make([]int, len(r.Days))
+	for i, day := range r.Days {
+		values[i] = day.Completed
+	}
+	return values
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a line of block characters, scaled to the largest value
+func Sparkline(values []int) string {
+	max := 0
+	for _, value := range values {
+		if value > max {
+			max = value
+		}
+	}
+
+	var line strings.Builder
+	for _, value := range values {
+		if max == 0 {
+			line.WriteRune(sparks[0])
+			continue
+		}
+		line.WriteRune(sparks[value*(len(sparks)-1)/max])
+	}
+
+	return line.String()
+}
+
+// StartOfDay truncates a time to the midnight of its (local) day
+func StartOfDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// StartOfWeek finds the midnight of Monday of the week of t
+func StartOfWeek(t time.Time) time.Time {
+	day := StartOfDay(t)
+	offset := (int(day.Weekday()) + 6) % 7 // Days since Monday
+	return day.AddDate(0, 0, -offset)
+}
+
+func inRange(t, from, to time.Time) bool {
+	return !t.IsZero() && !t.Before(from) && t.Before(to)
+}
+
+func dayIndex(from, t time.Time) int {
+	index := 0
+	for day := from.AddDate(0, 0, 1); !day.After(t); day = day.AddDate(0, 0, 1) {
+		index++
+	}
+	return index
+}
+
+func groupByWeek(days []Period) []Period {
+	var weeks []Period
+	for _, day := range days {
+		weekStart := StartOfWeek(day.Start)
+		if len(weeks) == 0 || !weeks[len(weeks)-1].Start.Equal(weekStart) {
+			weeks = append(weeks, Period{Start: weekStart})
+		}
+		weeks[len(weeks)-1].Created += day.Created
+		weeks[len(weeks)-1].Completed += day.Completed
+	}
+
+	return weeks
+}
@@ -0,0 +1,234 @@
+// Package taskwarrior reads and writes tasks in the JSON format of Taskwarrior (`task export` / `task import`)
+package taskwarrior
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+	"github.com/ajaxray/geek-life/util"
+)
+
+const timeLayout = "20060102T150405Z"
+
+// Task statuses of Taskwarrior
+const (
+	StatusPending   = "pending"
+	StatusCompleted = "completed"
+	StatusDeleted   = "deleted"
+	StatusWaiting   = "waiting"
+	StatusRecurring = "recurring" // Template of recurring tasks, the occurrences are pending tasks
+)
+
+// Task is a task as exported by Taskwarrior. Attributes geek-life doesn't use are left out.
+type Task struct {
+	UUID        string       `json:"uuid"`
+	Description string       `json:"description"`
+	Status      string       `json:"status"`
+	Project     string       `json:"project,omitempty"`
+	Priority    string       `json:"priority,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+	Annotations []Annotation `json:"annotations,omitempty"`
+	Entry       *Time        `json:"entry,omitempty"`
+	Modified    *Time        `json:"modified,omitempty"`
+	Due         *Time        `json:"due,omitempty"`
+	Wait        *Time        `json:"wait,omitempty"`      // Hidden until then, start date of geek-life
+	Scheduled   *Time        `json:"scheduled,omitempty"` // Earliest time to start, if there is no wait
+	End         *Time        `json:"end,omitempty"`
+}
+
+// Annotation is a timestamped note of a task
+type Annotation struct {
+	Entry       *Time  `json:"entry,omitempty"`
+	Description string `json:"description"`
+}
+
+// Time is a timestamp in Taskwarrior's format, e.g. 20240131T183000Z
+type Time struct {
+	time.Time
+}
+
+// NewTime makes a Time of t, nil for zero time
+func NewTime(t time.Time) *Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &Time{t}
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UTC().Format(timeLayout))
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parsed, err := time.Parse(timeLayout, value)
+	if err != nil {
+		// Some versions write RFC 3339 timestamps
+		if parsed, err = time.Parse(time.RFC3339, value); err != nil {
+			return fmt.Errorf("invalid time %q", value)
+		}
+	}
+
+	t.Time = parsed
+	return nil
+}
+
+// Read parses output of `task export`: a JSON array, or one JSON object per line (Taskwarrior before 2.6)
+func Read(r io.Reader) ([]Task, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []Task
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		err := json.Unmarshal(trimmed, &tasks)
+		return tasks, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSuffix(strings.TrimSpace(scanner.Text()), ",")
+		if line == "" {
+			continue
+		}
+
+		var task Task
+		if err := json.Unmarshal([]byte(line), &task); err != nil {
+			return tasks, fmt.Errorf("line %d: %w", number, err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, scanner.Err()
+}
+
+// Write writes tasks as a JSON array for `task import`
+func Write(w io.Writer, tasks []model.Task, projects []model.Project) error {
+	projectTitles := make(map[int64]string, len(projects))
+	for _, project := range projects {
+		projectTitles[project.ID] = project.Title
+	}
+
+	exported := make([]Task, 0, len(tasks))
+	for _, task := range tasks {
+		exported = append(exported, FromTask(task, projectTitles[task.ProjectID]))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(exported)
+}
+
+// FromTask converts a geek-life task. Every line of task note becomes an annotation.
+func FromTask(task model.Task, project string) Task {
+	exported := Task{
+		UUID:        UUID(task),
+		Description: task.Title,
+		Status:      StatusPending,
+		Project:     project,
+		Priority:    task.Priority,
+		Tags:        task.Tags,
+		Entry:       NewTime(task.CreatedAt),
+		Modified:    NewTime(task.UpdatedAt),
+	}
+	if exported.Entry == nil {
+		// entry is required by `task import`
+		exported.Entry = NewTime(time.Now())
+	}
+
+	if task.Completed {
+		exported.Status = StatusCompleted
+		exported.End = exported.Modified
+		if task.CompletedAt != nil {
+			exported.End = NewTime(*task.CompletedAt)
+		}
+	}
+	if task.DueTime != "" {
+		exported.Due = NewTime(task.DueAt(time.Local))
+	} else if task.DueDate != 0 {
+		exported.Due = NewTime(task.DueDay(time.Local))
+	}
+	if task.StartDate != 0 {
+		exported.Wait = NewTime(task.StartDay(time.Local))
+	}
+
+	for _, line := range strings.Split(task.Details, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			exported.Annotations = append(exported.Annotations, Annotation{Entry: exported.Entry, Description: line})
+		}
+	}
+
+	return exported
+}
+
+// Apply copies attributes of a Taskwarrior task to a geek-life task. Annotations become lines of task note.
+func (t Task) Apply(task *model.Task) {
+	task.Title = strings.TrimSpace(t.Description)
+	task.Priority = t.Priority
+	task.Tags = t.Tags
+
+	var notes []string
+	for _, annotation := range t.Annotations {
+		notes = append(notes, annotation.Description)
+	}
+	task.Details = strings.Join(notes, "\n")
+
+	// Due at midnight is due any time of the day
+	task.SetDueDay(time.Time{})
+	if t.Due != nil {
+		due := t.Due.In(task.Location())
+		task.SetDueDay(due)
+		if due.Hour() != 0 || due.Minute() != 0 {
+			task.DueTime = due.Format("15:04")
+		}
+	}
+	task.SetStartDay(time.Time{})
+	if start := t.Wait; start != nil || t.Scheduled != nil {
+		if start == nil {
+			start = t.Scheduled
+		}
+		task.SetStartDay(start.In(task.Location()))
+	}
+
+	completed := t.Status == StatusCompleted
+	if completed != task.Completed {
+		task.CompletedAt = nil
+		if completed && t.End != nil {
+			end := t.End.Local()
+			task.CompletedAt = &end
+		}
+	}
+	task.Completed = completed
+
+	if task.CreatedAt.IsZero() && t.Entry != nil {
+		task.CreatedAt = t.Entry.Local()
+	}
+}
+
+// UUID gives the identifier of a task for Taskwarrior, which accepts only UUIDs.
+// Tasks with other identifiers (e.g. imported from calendars) get a UUID made from the identifier or ID.
+func UUID(task model.Task) string {
+	if util.IsUUID(task.UUID) {
+		return strings.ToLower(task.UUID)
+	}
+
+	name := task.UUID
+	if name == "" {
+		name = fmt.Sprintf("task-%d@geek-life", task.ID)
+	}
+
+	// Name based, so that it stays the same in every export
+	return util.NameUUID(name)
+}
@@ -0,0 +1,150 @@
+package taskwarrior
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+)
+
+const pendingJSON = `{"uuid":"5f7c2b4e-0d6a-4d7e-9a3b-1c2d3e4f5a6b","description":"Pay rent","status":"pending","project":"Home","tags":["bills"],` +
+	`"entry":"20240101T080000Z","due":"20240131T000000Z","annotations":[{"entry":"20240101T080000Z","description":"By transfer"}]}`
+
+const completedJSON = `{"uuid":"8a9b0c1d-2e3f-4a5b-8c7d-6e5f4a3b2c1d","description":"Call the bank","status":"completed","project":"Home.Money",` +
+	`"entry":"2024-01-02T08:00:00Z","due":"20240131T183000Z","wait":"20240129T000000Z","end":"20240130T100000Z"}`
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name   string
+		export string
+	}{
+		{"array", "[\n" + pendingJSON + ",\n" + completedJSON + "\n]\n"},
+		{"lines", pendingJSON + "\n\n" + completedJSON + "\n"},
+		{"lines with commas", pendingJSON + ",\n" + completedJSON + ",\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := Read(strings.NewReader(tt.export))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tasks) != 2 {
+				t.Fatalf("got %d tasks, want 2", len(tasks))
+			}
+
+			pending, completed := tasks[0], tasks[1]
+			if pending.Status != StatusPending || pending.Project != "Home" || pending.Description != "Pay rent" {
+				t.Errorf("first task = %+v", pending)
+			}
+			if completed.Status != StatusCompleted || completed.Project != "Home.Money" {
+				t.Errorf("second task = %+v", completed)
+			}
+			if want := time.Date(2024, time.January, 2, 8, 0, 0, 0, time.UTC); !completed.Entry.Equal(want) {
+				t.Errorf("entry = %v, want %v", completed.Entry, want)
+			}
+		})
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		export string
+	}{
+		{"broken array", "[" + pendingJSON},
+		{"broken line", pendingJSON + "\n{\"uuid\":\n"},
+		{"not JSON", "uuid: 5f7c2b4e"},
+		{"invalid time", `{"uuid":"u1","description":"Late","status":"pending","due":"tomorrow"}`},
+		{"time as number", `[{"uuid":"u1","description":"Late","status":"pending","due":20240131}]`},
+		{"status as number", `{"uuid":"u1","description":"Odd","status":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Read(strings.NewReader(tt.export)); err == nil {
+				t.Error("Read() gave no error")
+			}
+		})
+	}
+}
+
+func TestReadEmpty(t *testing.T) {
+	for _, export := range []string{"", "\n", "[]", " [ ] "} {
+		tasks, err := Read(strings.NewReader(export))
+		if err != nil || len(tasks) != 0 {
+			t.Errorf("Read(%q) = %v, %v, want no tasks", export, tasks, err)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC) }
+	at := func(t time.Time) *Time { return &Time{t} }
+	completedAt := time.Date(2024, time.January, 30, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		tw        Task
+		task      model.Task // Before applying
+		due       time.Time
+		dueTime   string
+		start     time.Time
+		completed bool
+		end       *time.Time
+	}{
+		{name: "pending without dates", tw: Task{Status: StatusPending}},
+		{name: "due day at midnight", tw: Task{Status: StatusPending, Due: at(day(31))}, due: day(31)},
+		{name: "due at a time", tw: Task{Status: StatusPending, Due: at(day(31).Add(18*time.Hour + 30*time.Minute))},
+			due: day(31), dueTime: "18:30"},
+		{name: "due removed", tw: Task{Status: StatusPending},
+			task: model.Task{DueDate: day(31).Unix(), DueTime: "09:00"}},
+		{name: "wait", tw: Task{Status: StatusPending, Wait: at(day(29)), Scheduled: at(day(28))}, start: day(29)},
+		{name: "scheduled", tw: Task{Status: StatusPending, Scheduled: at(day(28))}, start: day(28)},
+		{name: "completed", tw: Task{Status: StatusCompleted, End: at(completedAt)}, completed: true, end: &completedAt},
+		{name: "completed without end", tw: Task{Status: StatusCompleted}, completed: true},
+		{name: "still completed", tw: Task{Status: StatusCompleted, End: at(completedAt.Add(time.Hour))},
+			task: model.Task{Completed: true, CompletedAt: &completedAt}, completed: true, end: &completedAt},
+		{name: "reopened", tw: Task{Status: StatusPending},
+			task: model.Task{Completed: true, CompletedAt: &completedAt}},
+		{name: "waiting", tw: Task{Status: StatusWaiting, Wait: at(day(29))}, start: day(29)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := tt.task
+			task.TimeZone = "UTC" // Dates of the test, whatever the local time zone is
+			tt.tw.Apply(&task)
+
+			if got := task.DueDay(time.UTC); !got.Equal(tt.due) || task.DueTime != tt.dueTime {
+				t.Errorf("due = %v %q, want %v %q", got, task.DueTime, tt.due, tt.dueTime)
+			}
+			if got := task.StartDay(time.UTC); !got.Equal(tt.start) {
+				t.Errorf("start = %v, want %v", got, tt.start)
+			}
+			if task.Completed != tt.completed {
+				t.Errorf("completed = %v, want %v", task.Completed, tt.completed)
+			}
+			if (task.CompletedAt == nil) != (tt.end == nil) || (task.CompletedAt != nil && !task.CompletedAt.Equal(*tt.end)) {
+				t.Errorf("completed at = %v, want %v", task.CompletedAt, tt.end)
+			}
+		})
+	}
+}
+
+func TestApplyAttributes(t *testing.T) {
+	tasks, err := Read(strings.NewReader(pendingJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var task model.Task
+	tasks[0].Apply(&task)
+	if task.Title != "Pay rent" || task.Details != "By transfer" || len(task.Tags) != 1 || task.Tags[0] != "bills" {
+		t.Errorf("Apply() = %+v", task)
+	}
+	if want := time.Date(2024, time.January, 1, 8, 0, 0, 0, time.UTC); !task.CreatedAt.Equal(want) {
+		t.Errorf("created at = %v, want %v", task.CreatedAt, want)
+	}
+}
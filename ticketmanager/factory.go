@@ -14,10 +14,53 @@ const (
 	ProviderLinear ProviderType = "linear"
 )
 
+// AllProviders lists every supported ticket provider
+var AllProviders = []ProviderType{ProviderJira, ProviderLinear}
+
+// DisplayName returns a human friendly name of the provider
+func (p ProviderType) DisplayName() string {
+	switch p {
+	case ProviderJira:
+		return "JIRA"
+	case ProviderLinear:
+		return "Linear"
+	default:
+		return string(p)
+	}
+}
+
+// IsConfigured checks if required environment variables of the provider are set
+func (p ProviderType) IsConfigured() bool {
+	switch p {
+	case ProviderJira:
+		return util.GetJiraConfig().IsConfigured()
+	case ProviderLinear:
+		return GetLinearConfig().IsConfigured()
+	default:
+		return false
+	}
+}
+
+// TicketURL builds the browser URL of an epic or task key for the provider
+func (p ProviderType) TicketURL(key string) string {
+	switch p {
+	case ProviderJira:
+		return fmt.Sprintf("%s/browse/%s", util.GetJiraConfig().URL, key)
+	case ProviderLinear:
+		return fmt.Sprintf("https://linear.app/team/issue/%s", key)
+	default:
+		return ""
+	}
+}
+
+// NewTicketManager creates the TicketManager of default provider (TICKET_PROVIDER)
 func NewTicketManager() (TicketManager, error) {
-	provider := strings.ToLower(util.GetEnvStr("TICKET_PROVIDER", "jira"))
+	return NewTicketManagerFor(GetProviderType())
+}
 
-	switch ProviderType(provider) {
+// NewTicketManagerFor creates the TicketManager of given provider
+func NewTicketManagerFor(provider ProviderType) (TicketManager, error) {
+	switch provider {
 	case ProviderJira:
 		jiraConfig := util.GetJiraConfig()
 		if !jiraConfig.IsConfigured() {
@@ -44,14 +87,18 @@ func NewTicketManager() (TicketManager, error) {
 	}
 }
 
+// GetProviderType returns the default provider, used for projects not linked to any provider yet
 func GetProviderType() ProviderType {
 	provider := strings.ToLower(util.GetEnvStr("TICKET_PROVIDER", "jira"))
 	return ProviderType(provider)
 }
 
 func IsAnyProviderConfigured() bool {
-	jiraConfig := util.GetJiraConfig()
-	linearConfig := GetLinearConfig()
+	for _, provider := range AllProviders {
+		if provider.IsConfigured() {
+			return true
+		}
+	}
 
-	return jiraConfig.IsConfigured() || linearConfig.IsConfigured()
+	return false
 }
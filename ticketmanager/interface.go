@@ -11,6 +11,8 @@ type TicketManager interface {
 	// Task management
 	CreateTask(title, description string, epicID string) (string, error)
 	UpdateTask(title, description string, completed bool, taskID string) error
+	MoveTask(taskID, epicID string) error
+	TransitionTask(taskID, status string) (string, error)
 	ListTasksForEpic(epicID string) ([]Task, error)
 	DescribeTask(taskID string) (*Task, error)
 }
@@ -23,17 +25,22 @@ type Epic struct {
 	Status      string `json:"status"`
 	Creator     User   `json:"creator"`
 	CreatedDate string `json:"createdDate"`
+	UpdatedDate string `json:"updatedDate"`
 }
 
 type Task struct {
-	ID          string `json:"id"`
-	Key         string `json:"key"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	Completed   bool   `json:"completed"`
-	EpicID      string `json:"epicId"`
-	Creator     User   `json:"creator"`
+	ID             string `json:"id"`
+	Key            string `json:"key"`
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	Status         string `json:"status"`         // Name of workflow status
+	StatusCategory string `json:"statusCategory"` // Jira status category or Linear state type
+	Completed      bool   `json:"completed"`
+	EpicID         string `json:"epicId"`
+	URL            string `json:"url"`
+	Creator        User   `json:"creator"`
+	CreatedDate    string `json:"createdDate"`
+	UpdatedDate    string `json:"updatedDate"`
 }
 
 type User struct {
@@ -2,6 +2,7 @@ package ticketmanager
 
 import (
 	"github.com/ajaxray/geek-life/jira"
+	"github.com/ajaxray/geek-life/model"
 	"github.com/ajaxray/geek-life/util"
 )
 
@@ -53,6 +54,7 @@ func (j *JiraTicketManager) ListEpics() ([]Epic, error) {
 				DisplayName: je.Fields.Creator.DisplayName,
 			},
 			CreatedDate: je.Fields.Created,
+			UpdatedDate: je.Fields.Updated,
 		}
 	}
 
@@ -79,6 +81,7 @@ func (j *JiraTicketManager) ListUserEpics() ([]Epic, error) {
 				DisplayName: je.Fields.Creator.DisplayName,
 			},
 			CreatedDate: je.Fields.Created,
+			UpdatedDate: je.Fields.Updated,
 		}
 	}
 
@@ -103,6 +106,7 @@ func (j *JiraTicketManager) DescribeEpic(epicID string) (*Epic, error) {
 			DisplayName: jiraEpic.Fields.Creator.DisplayName,
 		},
 		CreatedDate: jiraEpic.Fields.Created,
+		UpdatedDate: jiraEpic.Fields.Updated,
 	}, nil
 }
 
@@ -118,6 +122,23 @@ func (j *JiraTicketManager) UpdateTask(
 	return j.client.UpdateTask(title, description, completed, taskID)
 }
 
+func (j *JiraTicketManager) MoveTask(taskID, epicID string) error {
+	return j.client.MoveTask(taskID, epicID)
+}
+
+// jiraCategories are the status categories of task statuses.
+// Other statuses (e.g. Blocked) share a category with In Progress or Done, they are found by name only.
+var jiraCategories = map[string]string{
+	model.StatusTodo:       jira.CategoryNew,
+	model.StatusInProgress: jira.CategoryInProgress,
+	model.StatusDone:       jira.CategoryDone,
+}
+
+// TransitionTask moves a task to the workflow status of a common name of the status, or else of the same category
+func (j *JiraTicketManager) TransitionTask(taskID, status string) (string, error) {
+	return j.client.TransitionTask(taskID, remoteStatusNames[status], jiraCategories[status])
+}
+
 func (j *JiraTicketManager) ListTasksForEpic(epicID string) ([]Task, error) {
 	jiraTasks, err := j.client.ListTasksForEpic(epicID)
 	if err != nil {
@@ -127,18 +148,21 @@ func (j *JiraTicketManager) ListTasksForEpic(epicID string) ([]Task, error) {
 	tasks := make([]Task, len(jiraTasks))
 	for i, jt := range jiraTasks {
 		tasks[i] = Task{
-			ID:          jt.ID,
-			Key:         jt.Key,
-			Title:       jt.Fields.Summary,
-			Description: getDescriptionString(jt.Fields.Description),
-			Status:      jt.Fields.Status.Name,
-			Completed:   jt.Fields.Status.StatusCategory.Key == "done",
-			EpicID:      epicID,
+			ID:             jt.ID,
+			Key:            jt.Key,
+			Title:          jt.Fields.Summary,
+			Description:    getDescriptionString(jt.Fields.Description),
+			Status:         jt.Fields.Status.Name,
+			StatusCategory: jt.Fields.Status.StatusCategory.Key,
+			Completed:      jt.Fields.Status.StatusCategory.Key == "done",
+			EpicID:         epicID,
 			Creator: User{
 				ID:          jt.Fields.Creator.AccountID,
 				Email:       jt.Fields.Creator.EmailAddress,
 				DisplayName: jt.Fields.Creator.DisplayName,
 			},
+			CreatedDate: jt.Fields.Created,
+			UpdatedDate: jt.Fields.Updated,
 		}
 	}
 
@@ -152,17 +176,20 @@ func (j *JiraTicketManager) DescribeTask(taskID string) (*Task, error) {
 	}
 
 	return &Task{
-		ID:          jiraTask.ID,
-		Key:         jiraTask.Key,
-		Title:       jiraTask.Fields.Summary,
-		Description: getDescriptionString(jiraTask.Fields.Description),
-		Status:      jiraTask.Fields.Status.Name,
-		Completed:   jiraTask.Fields.Status.StatusCategory.Key == "done",
+		ID:             jiraTask.ID,
+		Key:            jiraTask.Key,
+		Title:          jiraTask.Fields.Summary,
+		Description:    getDescriptionString(jiraTask.Fields.Description),
+		Status:         jiraTask.Fields.Status.Name,
+		StatusCategory: jiraTask.Fields.Status.StatusCategory.Key,
+		Completed:      jiraTask.Fields.Status.StatusCategory.Key == "done",
 		Creator: User{
 			ID:          jiraTask.Fields.Creator.AccountID,
 			Email:       jiraTask.Fields.Creator.EmailAddress,
 			DisplayName: jiraTask.Fields.Creator.DisplayName,
 		},
+		CreatedDate: jiraTask.Fields.Created,
+		UpdatedDate: jiraTask.Fields.Updated,
 	}, nil
 }
 
@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ajaxray/geek-life/model"
 	"github.com/ajaxray/geek-life/util"
 )
 
@@ -272,6 +274,7 @@ func (l *LinearTicketManager) ListEpics() ([]Epic, error) {
 					description
 					state
 					createdAt
+					updatedAt
 					creator {
 						id
 						email
@@ -308,6 +311,7 @@ func (l *LinearTicketManager) ListEpics() ([]Epic, error) {
 					Description string `json:"description"`
 					State       string `json:"state"`
 					CreatedAt   string `json:"createdAt"`
+					UpdatedAt   string `json:"updatedAt"`
 					Creator     struct {
 						ID          string `json:"id"`
 						Email       string `json:"email"`
@@ -337,6 +341,7 @@ func (l *LinearTicketManager) ListEpics() ([]Epic, error) {
 				DisplayName: project.Creator.DisplayName,
 			},
 			CreatedDate: project.CreatedAt,
+			UpdatedDate: project.UpdatedAt,
 		}
 	}
 
@@ -394,6 +399,7 @@ func (l *LinearTicketManager) ListUserEpics() ([]Epic, error) {
 						description
 						state
 						createdAt
+						updatedAt
 						creator {
 							id
 							email
@@ -424,6 +430,7 @@ func (l *LinearTicketManager) ListUserEpics() ([]Epic, error) {
 						Description string `json:"description"`
 						State       string `json:"state"`
 						CreatedAt   string `json:"createdAt"`
+						UpdatedAt   string `json:"updatedAt"`
 						Creator     struct {
 							ID          string `json:"id"`
 							Email       string `json:"email"`
@@ -456,6 +463,7 @@ func (l *LinearTicketManager) ListUserEpics() ([]Epic, error) {
 					DisplayName: project.Creator.DisplayName,
 				},
 				CreatedDate: project.CreatedAt,
+				UpdatedDate: project.UpdatedAt,
 			})
 		}
 	}
@@ -472,6 +480,7 @@ func (l *LinearTicketManager) DescribeEpic(epicID string) (*Epic, error) {
 				description
 				state
 				createdAt
+				updatedAt
 				creator {
 					id
 					email
@@ -498,6 +507,7 @@ func (l *LinearTicketManager) DescribeEpic(epicID string) (*Epic, error) {
 				Description string `json:"description"`
 				State       string `json:"state"`
 				CreatedAt   string `json:"createdAt"`
+				UpdatedAt   string `json:"updatedAt"`
 				Creator     struct {
 					ID          string `json:"id"`
 					Email       string `json:"email"`
@@ -525,6 +535,7 @@ func (l *LinearTicketManager) DescribeEpic(epicID string) (*Epic, error) {
 			DisplayName: project.Creator.DisplayName,
 		},
 		CreatedDate: project.CreatedAt,
+		UpdatedDate: project.UpdatedAt,
 	}, nil
 }
 
@@ -607,49 +618,13 @@ func (l *LinearTicketManager) UpdateTask(
 	if completed {
 		// Get completed state ID - this would need to be configured per team
 		// For now, we'll assume there's a "Done" state
-		stateQuery := `
-			query GetStates($teamId: String!) {
-				team(id: $teamId) {
-					states {
-						nodes {
-							id
-							name
-						}
-					}
-				}
-			}
-		`
-
-		teamID, err := l.getTeamID()
-		if err != nil {
-			return err
-		}
-
-		stateResp, err := l.makeRequest(stateQuery, map[string]interface{}{"teamId": teamID})
-		if err != nil {
-			return err
-		}
-
-		var stateResult struct {
-			Data struct {
-				Team struct {
-					States struct {
-						Nodes []struct {
-							ID   string `json:"id"`
-							Name string `json:"name"`
-						} `json:"nodes"`
-					} `json:"states"`
-				} `json:"team"`
-			} `json:"data"`
-		}
-
-		err = json.Unmarshal(stateResp, &stateResult)
+		states, err := l.workflowStates()
 		if err != nil {
 			return err
 		}
 
 		// Find Done state
-		for _, state := range stateResult.Data.Team.States.Nodes {
+		for _, state := range states {
 			if state.Name == "Done" || state.Name == "Completed" {
 				input["stateId"] = state.ID
 				break
@@ -687,6 +662,169 @@ func (l *LinearTicketManager) UpdateTask(
 	return nil
 }
 
+func (l *LinearTicketManager) MoveTask(taskID, epicID string) error {
+	query := `
+		mutation MoveIssue($id: String!, $input: IssueUpdateInput!) {
+			issueUpdate(id: $id, input: $input) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": taskID,
+		"input": map[string]interface{}{
+			"projectId": epicID,
+		},
+	}
+
+	resp, err := l.makeRequest(query, variables)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Data struct {
+			IssueUpdate struct {
+				Success bool `json:"success"`
+			} `json:"issueUpdate"`
+		} `json:"data"`
+	}
+
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return err
+	}
+
+	if !result.Data.IssueUpdate.Success {
+		return fmt.Errorf("failed to move task in Linear")
+	}
+
+	return nil
+}
+
+// linearState is a workflow state of team
+type linearState struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"` // backlog, unstarted, started, completed or canceled
+}
+
+// workflowStates lists the workflow states of the team
+func (l *LinearTicketManager) workflowStates() ([]linearState, error) {
+	query := `
+		query GetStates($teamId: String!) {
+			team(id: $teamId) {
+				states {
+					nodes {
+						id
+						name
+						type
+					}
+				}
+			}
+		}
+	`
+
+	teamID, err := l.getTeamID()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := l.makeRequest(query, map[string]interface{}{"teamId": teamID})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data struct {
+			Team struct {
+				States struct {
+					Nodes []linearState `json:"nodes"`
+				} `json:"states"`
+			} `json:"team"`
+		} `json:"data"`
+	}
+
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data.Team.States.Nodes, nil
+}
+
+// linearStateTypes are the workflow state types of task statuses.
+// Other statuses (e.g. Blocked) are custom states of a team, they are found by name only.
+var linearStateTypes = map[string]string{
+	model.StatusTodo:       "unstarted",
+	model.StatusInProgress: "started",
+	model.StatusDone:       "completed",
+	model.StatusCancelled:  "canceled",
+}
+
+// TransitionTask moves a task to the workflow state of a common name of the status, or else of the same type
+func (l *LinearTicketManager) TransitionTask(taskID, status string) (string, error) {
+	states, err := l.workflowStates()
+	if err != nil {
+		return "", err
+	}
+
+	var target *linearState
+	for _, name := range remoteStatusNames[status] {
+		for i := range states {
+			if target == nil && strings.EqualFold(states[i].Name, name) {
+				target = &states[i]
+			}
+		}
+	}
+	for i := range states {
+		if target == nil && states[i].Type == linearStateTypes[status] {
+			target = &states[i]
+		}
+	}
+	if target == nil {
+		return "", fmt.Errorf("no workflow state %q found in Linear", status)
+	}
+
+	query := `
+		mutation TransitionIssue($id: String!, $input: IssueUpdateInput!) {
+			issueUpdate(id: $id, input: $input) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    taskID,
+		"input": map[string]interface{}{"stateId": target.ID},
+	}
+
+	resp, err := l.makeRequest(query, variables)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Data struct {
+			IssueUpdate struct {
+				Success bool `json:"success"`
+			} `json:"issueUpdate"`
+		} `json:"data"`
+	}
+
+	err = json.Unmarshal(resp, &result)
+	if err != nil {
+		return "", err
+	}
+
+	if !result.Data.IssueUpdate.Success {
+		return "", fmt.Errorf("failed to change status of task in Linear")
+	}
+
+	return target.Name, nil
+}
+
 func (l *LinearTicketManager) ListTasksForEpic(epicID string) ([]Task, error) {
 	query := `
 		query GetProjectIssues($filter: IssueFilter!) {
@@ -694,10 +832,14 @@ func (l *LinearTicketManager) ListTasksForEpic(epicID string) ([]Task, error) {
 				nodes {
 					id
 					identifier
+					url
+					createdAt
+					updatedAt
 					title
 					description
 					state {
 						name
+						type
 					}
 					project {
 						id
@@ -733,10 +875,14 @@ func (l *LinearTicketManager) ListTasksForEpic(epicID string) ([]Task, error) {
 				Nodes []struct {
 					ID          string `json:"id"`
 					Identifier  string `json:"identifier"`
+					URL         string `json:"url"`
+					CreatedAt   string `json:"createdAt"`
+					UpdatedAt   string `json:"updatedAt"`
 					Title       string `json:"title"`
 					Description string `json:"description"`
 					State       struct {
 						Name string `json:"name"`
+						Type string `json:"type"`
 					} `json:"state"`
 					Project struct {
 						ID string `json:"id"`
@@ -759,13 +905,17 @@ func (l *LinearTicketManager) ListTasksForEpic(epicID string) ([]Task, error) {
 	tasks := make([]Task, len(result.Data.Issues.Nodes))
 	for i, issue := range result.Data.Issues.Nodes {
 		tasks[i] = Task{
-			ID:          issue.ID,
-			Key:         issue.Identifier,
-			Title:       issue.Title,
-			Description: issue.Description,
-			Status:      issue.State.Name,
-			Completed:   issue.State.Name == "Done" || issue.State.Name == "Completed",
-			EpicID:      issue.Project.ID,
+			ID:             issue.ID,
+			Key:            issue.Identifier,
+			Title:          issue.Title,
+			Description:    issue.Description,
+			Status:         issue.State.Name,
+			StatusCategory: issue.State.Type,
+			Completed:      issue.State.Type == "completed" || issue.State.Type == "canceled",
+			EpicID:         issue.Project.ID,
+			URL:            issue.URL,
+			CreatedDate:    issue.CreatedAt,
+			UpdatedDate:    issue.UpdatedAt,
 			Creator: User{
 				ID:          issue.Creator.ID,
 				Email:       issue.Creator.Email,
@@ -783,10 +933,14 @@ func (l *LinearTicketManager) DescribeTask(taskID string) (*Task, error) {
 			issue(id: $id) {
 				id
 				identifier
+				url
+				createdAt
+				updatedAt
 				title
 				description
 				state {
 					name
+					type
 				}
 				project {
 					id
@@ -814,10 +968,14 @@ func (l *LinearTicketManager) DescribeTask(taskID string) (*Task, error) {
 			Issue struct {
 				ID          string `json:"id"`
 				Identifier  string `json:"identifier"`
+				URL         string `json:"url"`
+				CreatedAt   string `json:"createdAt"`
+				UpdatedAt   string `json:"updatedAt"`
 				Title       string `json:"title"`
 				Description string `json:"description"`
 				State       struct {
 					Name string `json:"name"`
+					Type string `json:"type"`
 				} `json:"state"`
 				Project struct {
 					ID string `json:"id"`
@@ -838,13 +996,17 @@ func (l *LinearTicketManager) DescribeTask(taskID string) (*Task, error) {
 
 	issue := result.Data.Issue
 	return &Task{
-		ID:          issue.ID,
-		Key:         issue.Identifier,
-		Title:       issue.Title,
-		Description: issue.Description,
-		Status:      issue.State.Name,
-		Completed:   issue.State.Name == "Done" || issue.State.Name == "Completed",
-		EpicID:      issue.Project.ID,
+		ID:             issue.ID,
+		Key:            issue.Identifier,
+		Title:          issue.Title,
+		Description:    issue.Description,
+		Status:         issue.State.Name,
+		StatusCategory: issue.State.Type,
+		Completed:      issue.State.Type == "completed" || issue.State.Type == "canceled",
+		EpicID:         issue.Project.ID,
+		URL:            issue.URL,
+		CreatedDate:    issue.CreatedAt,
+		UpdatedDate:    issue.UpdatedAt,
 		Creator: User{
 			ID:          issue.Creator.ID,
 			Email:       issue.Creator.Email,
@@ -0,0 +1,74 @@
+package ticketmanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ajaxray/geek-life/model"
+)
+
+// ParseDate parses the ISO 8601 timestamps returned by ticket providers
+func ParseDate(dateStr string) (time.Time, error) {
+	// JIRA typically returns dates like "2023-10-15T14:30:00.000+0000", Linear uses RFC3339
+	layouts := []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05.000-0700",
+		"2006-01-02T15:04:05.000+0000",
+		"2006-01-02T15:04:05-0700",
+		"2006-01-02T15:04:05+0000",
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, dateStr); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
+}
+
+// EpicLink builds the ExternalLink of a project connected with an epic
+func EpicLink(provider ProviderType, epic Epic) model.ExternalLink {
+	return model.ExternalLink{
+		Provider:        string(provider),
+		ExternalID:      epic.ID,
+		ExternalKey:     epic.Key,
+		ExternalURL:     provider.TicketURL(epic.Key),
+		RemoteCreatedAt: parseDatePtr(epic.CreatedDate),
+		RemoteUpdatedAt: parseDatePtr(epic.UpdatedDate),
+	}
+}
+
+// TaskLink builds the ExternalLink of a task connected with a remote task
+func TaskLink(provider ProviderType, task Task) model.ExternalLink {
+	url := task.URL
+	if url == "" {
+		url = provider.TicketURL(task.Key)
+	}
+
+	return model.ExternalLink{
+		Provider:        string(provider),
+		ExternalID:      task.ID,
+		ExternalKey:     task.Key,
+		ExternalURL:     url,
+		RemoteCreatedAt: parseDatePtr(task.CreatedDate),
+		RemoteUpdatedAt: parseDatePtr(task.UpdatedDate),
+	}
+}
+
+// LinkURL provides browser URL of a link. Links saved without URL get the provider's browse URL.
+func LinkURL(link model.ExternalLink) string {
+	if link.ExternalURL != "" {
+		return link.ExternalURL
+	}
+
+	return ProviderType(link.Provider).TicketURL(link.ExternalKey)
+}
+
+func parseDatePtr(dateStr string) *time.Time {
+	if t, err := ParseDate(dateStr); err == nil {
+		return &t
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package ticketmanager
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Registry keeps one TicketManager for each configured provider.
+// It allows a single database to hold projects linked to different ticket systems.
+type Registry struct {
+	managers        map[ProviderType]TicketManager
+	defaultProvider ProviderType
+}
+
+// NewRegistry creates TicketManagers for all configured providers
+func NewRegistry() *Registry {
+	registry := &Registry{
+		managers:        make(map[ProviderType]TicketManager),
+		defaultProvider: GetProviderType(),
+	}
+
+	for _, provider := range AllProviders {
+		if !provider.IsConfigured() {
+			continue
+		}
+
+		if tm, err := NewTicketManagerFor(provider); err == nil {
+			registry.managers[provider] = tm
+		}
+	}
+
+	return registry
+}
+
+// Default returns the provider used for linking new projects
+func (r *Registry) Default() ProviderType {
+	return r.defaultProvider
+}
+
+// Resolve converts a stored provider name to ProviderType.
+// Records linked before providers were tracked have no name, they belong to the default provider.
+func (r *Registry) Resolve(provider string) ProviderType {
+	if provider == "" {
+		return r.defaultProvider
+	}
+
+	return ProviderType(strings.ToLower(provider))
+}
+
+// Get finds the TicketManager of a stored provider name
+func (r *Registry) Get(provider string) (TicketManager, error) {
+	resolved := r.Resolve(provider)
+	if tm, ok := r.managers[resolved]; ok {
+		return tm, nil
+	}
+
+	return nil, fmt.Errorf("%s is not configured. Set required environment variables", resolved.DisplayName())
+}
+
+// Providers lists configured providers, in the order of AllProviders
+func (r *Registry) Providers() []ProviderType {
+	var providers []ProviderType
+	for _, provider := range AllProviders {
+		if _, ok := r.managers[provider]; ok {
+			providers = append(providers, provider)
+		}
+	}
+
+	return providers
+}
+
+// IsConfigured checks if at least one provider is available
+func (r *Registry) IsConfigured() bool {
+	return len(r.managers) > 0
+}
@@ -0,0 +1,63 @@
+package ticketmanager
+
+import (
+	"strings"
+
+	"github.com/ajaxray/geek-life/model"
+)
+
+// remoteStatusNames are common names of workflow statuses in ticket providers, for every task status.
+// The first name is the preferred one when changing status of a ticket.
+var remoteStatusNames = map[string][]string{
+	model.StatusTodo:       {"To Do", "Todo", "Open", "New", "Backlog"},
+	model.StatusInProgress: {"In Progress", "Doing", "Started"},
+	model.StatusBlocked:    {"Blocked", "On Hold", "Impediment"},
+	model.StatusWaiting:    {"Waiting", "Pending", "In Review", "Review"},
+	model.StatusDone:       {"Done", "Closed", "Complete", "Completed", "Resolved"},
+	model.StatusCancelled:  {"Cancelled", "Canceled", "Won't Do", "Rejected", "Duplicate"},
+}
+
+// categoryStatuses are task statuses of workflow categories,
+// Jira status categories (new, indeterminate, done) and Linear state types (backlog, unstarted etc.)
+var categoryStatuses = map[string]string{
+	"new":           model.StatusTodo,
+	"backlog":       model.StatusTodo,
+	"triage":        model.StatusTodo,
+	"unstarted":     model.StatusTodo,
+	"indeterminate": model.StatusInProgress,
+	"started":       model.StatusInProgress,
+	"done":          model.StatusDone,
+	"completed":     model.StatusDone,
+	"canceled":      model.StatusCancelled,
+}
+
+// StatusOf is the task status of a ticket, by the name of its workflow status or else its category.
+// Names are matched first, as Blocked or Waiting statuses are in the same category as In Progress.
+func StatusOf(remoteName, category string) string {
+	name := strings.ToLower(remoteName)
+	for _, status := range []string{model.StatusBlocked, model.StatusWaiting, model.StatusCancelled} {
+		for _, candidate := range remoteStatusNames[status] {
+			if strings.Contains(name, strings.ToLower(candidate)) {
+				return status
+			}
+		}
+	}
+
+	if status, ok := categoryStatuses[category]; ok {
+		return status
+	}
+	for _, status := range model.Statuses {
+		for _, candidate := range remoteStatusNames[status] {
+			if strings.EqualFold(remoteName, candidate) {
+				return status
+			}
+		}
+	}
+
+	return model.StatusTodo
+}
+
+// TaskStatus is the task status of the ticket
+func (t Task) TaskStatus() string {
+	return StatusOf(t.Status, t.StatusCategory)
+}
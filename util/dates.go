@@ -0,0 +1,254 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateHelp lists the date expressions understood by ParseDate, e.g. for placeholders and errors
+const DateHelp = "today, tomorrow, fri, next fri, in 3 days, 2w, 1mo, eow, eom, dec 3, yyyy-mm-dd or none"
+
+// DateTime is a date with time of the day and time zone, if given. See ParseDateTime.
+type DateTime struct {
+	Date     time.Time // Midnight of the day, zero for no date
+	Clock    string    // Time of the day as 15:04, empty if not given
+	TimeZone string    // IANA name of the time zone, e.g. Europe/Berlin, empty if not given
+}
+
+var (
+	weekdays = map[string]time.Weekday{
+		"sun": time.Sunday, "sunday": time.Sunday,
+		"mon": time.Monday, "monday": time.Monday,
+		"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+		"wed": time.Wednesday, "wednesday": time.Wednesday,
+		"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+		"fri": time.Friday, "friday": time.Friday,
+		"sat": time.Saturday, "saturday": time.Saturday,
+	}
+
+	months = map[string]time.Month{
+		"jan": time.January, "january": time.January,
+		"feb": time.February, "february": time.February,
+		"mar": time.March, "march": time.March,
+		"apr": time.April, "april": time.April,
+		"may": time.May,
+		"jun": time.June, "june": time.June,
+		"jul": time.July, "july": time.July,
+		"aug": time.August, "august": time.August,
+		"sep": time.September, "sept": time.September, "september": time.September,
+		"oct": time.October, "october": time.October,
+		"nov": time.November, "november": time.November,
+		"dec": time.December, "december": time.December,
+	}
+
+	// e.g. "3 days", "in 2 weeks", "+1mo", "2w". Months are mo, as m is minutes in durations (see model.ParseBefore).
+	durationPattern = regexp.MustCompile(`^(?:in\s+|\+)?(\d+)\s*(d|days?|w|wks?|weeks?|mos?|months?|y|yrs?|years?)$`)
+	// e.g. "3", "3rd", "21st"
+	dayPattern = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?,?$`)
+	// e.g. "15:30", "3pm", "9:30am", "@15:00"
+	clockPattern = regexp.MustCompile(`^@?(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+)
+
+// ParseDate understands a date written in natural language (see DateHelp), relative to now.
+// The date is the midnight of the day, in the location of now.
+// Empty text, "none" or "no" mean no date, a zero time.
+func ParseDate(text string, now time.Time) (time.Time, error) {
+	text = strings.ToLower(strings.Join(strings.Fields(text), " "))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	nextMonday := today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7)
+
+	switch text {
+	case "", "none", "no", "never":
+		return time.Time{}, nil
+	case "today", "tod", "now", "eod":
+		return today, nil
+	case "tomorrow", "tmr", "tom", "tmrw":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "eow":
+		// Weeks are Monday to Sunday
+		return today.AddDate(0, 0, (7-int(today.Weekday()))%7), nil
+	case "next week":
+		return nextMonday, nil
+	case "eom":
+		return time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location()), nil
+	case "next month":
+		return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()), nil
+	case "eoy":
+		return time.Date(today.Year(), time.December, 31, 0, 0, 0, 0, today.Location()), nil
+	case "next year":
+		return time.Date(today.Year()+1, time.January, 1, 0, 0, 0, 0, today.Location()), nil
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", text, today.Location()); err == nil {
+		return date, nil
+	}
+
+	// Weekday of this week (the next one, if it's today or passed) or of next week
+	if weekday, ok := weekdays[text]; ok {
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), nil
+	}
+	if name := strings.TrimPrefix(text, "next "); name != text {
+		if weekday, ok := weekdays[name]; ok {
+			return nextMonday.AddDate(0, 0, (int(weekday)+6)%7), nil
+		}
+	}
+
+	if match := durationPattern.FindStringSubmatch(text); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		switch match[2][0] {
+		case 'd':
+			return today.AddDate(0, 0, n), nil
+		case 'w':
+			return today.AddDate(0, 0, 7*n), nil
+		case 'm':
+			return addMonths(today, n), nil
+		default:
+			return addMonths(today, 12*n), nil
+		}
+	}
+
+	if date, ok := parseMonthDay(text, today); ok {
+		return date, nil
+	}
+
+	return time.Time{}, fmt.Errorf("could not understand date %q, try %s", text, DateHelp)
+}
+
+// ParseDateTime understands a date (see ParseDate) followed by a time of the day and a time zone, both optional,
+// e.g. "fri 3pm", "tomorrow at 9:30", "dec 3 17:00 Europe/Berlin". A time without date is of today.
+func ParseDateTime(text string, now time.Time) (DateTime, error) {
+	var dt DateTime
+	words := strings.Fields(text)
+
+	if n := len(words); n > 0 && (strings.Contains(words[n-1], "/") || strings.EqualFold(words[n-1], "UTC")) {
+		name := words[n-1]
+		if strings.EqualFold(name, "UTC") {
+			name = "UTC"
+		}
+		if _, err := time.LoadLocation(name); err != nil {
+			return dt, fmt.Errorf("unknown time zone %q, use a name like Europe/Berlin or UTC", name)
+		}
+		dt.TimeZone = name
+		words = words[:n-1]
+	}
+
+	// e.g. "3 pm"
+	if n := len(words); n > 1 && (strings.EqualFold(words[n-1], "am") || strings.EqualFold(words[n-1], "pm")) {
+		words = append(words[:n-2], words[n-2]+words[n-1])
+	}
+	if n := len(words); n > 0 {
+		if clock, ok := parseClock(strings.ToLower(words[n-1])); ok {
+			dt.Clock = clock
+			words = words[:n-1]
+			if n = len(words); n > 0 && strings.EqualFold(words[n-1], "at") {
+				words = words[:n-1]
+			}
+		}
+	}
+
+	if len(words) == 0 && dt.Clock != "" {
+		dt.Date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		return dt, nil
+	}
+
+	date, err := ParseDate(strings.Join(words, " "), now)
+	if err != nil {
+		return dt, err
+	}
+	if dt.Date = date; date.IsZero() {
+		dt.Clock = ""
+	}
+
+	return dt, nil
+}
+
+// addMonths adds n months to a date. Days past the end of the month are its last day, e.g. Jan 31 + 1 month is Feb 28.
+func addMonths(date time.Time, n int) time.Time {
+	year, month, day := date.Date()
+	if last := time.Date(year, month+time.Month(n)+1, 0, 0, 0, 0, 0, date.Location()).Day(); day > last {
+		day = last
+	}
+
+	return time.Date(year, month+time.Month(n), day, 0, 0, 0, 0, date.Location())
+}
+
+// parseClock parses a time of the day, e.g. 15:30, 3pm or noon, as 15:04
+func parseClock(word string) (string, bool) {
+	if word == "noon" {
+		return "12:00", true
+	}
+
+	match := clockPattern.FindStringSubmatch(word)
+	if match == nil || (match[2] == "" && match[3] == "") {
+		return "", false // e.g. 3 of "dec 3"
+	}
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi("0" + match[2])
+
+	if match[3] != "" {
+		if hour < 1 || hour > 12 {
+			return "", false
+		}
+		hour %= 12
+		if match[3] == "pm" {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return "", false
+	}
+
+	return fmt.Sprintf("%02d:%02d", hour, minute), true
+}
+
+// parseMonthDay parses dates like "dec 3", "3rd december" or "dec 3, 2027".
+// Without year, it's the next such date from today (this year or next).
+func parseMonthDay(text string, today time.Time) (time.Time, bool) {
+	words := strings.Fields(text)
+	if len(words) < 2 || len(words) > 3 {
+		return time.Time{}, false
+	}
+
+	month, ok := months[words[0]]
+	dayWord := words[1]
+	if !ok {
+		month, ok = months[words[1]]
+		dayWord = words[0]
+	}
+	match := dayPattern.FindStringSubmatch(dayWord)
+	if !ok || match == nil {
+		return time.Time{}, false
+	}
+	day, _ := strconv.Atoi(match[1])
+
+	if len(words) == 3 {
+		year, err := strconv.Atoi(words[2])
+		if err != nil {
+			return time.Time{}, false
+		}
+		return validDate(year, month, day, today.Location())
+	}
+
+	// The next one, a few years later for feb 29
+	for year := today.Year(); year <= today.Year()+8; year++ {
+		if date, ok := validDate(year, month, day, today.Location()); ok && !date.Before(today) {
+			return date, true
+		}
+	}
+	return time.Time{}, false // e.g. feb 30
+}
+
+// validDate makes the date, if the month has the day
+func validDate(year int, month time.Month, day int, loc *time.Location) (time.Time, bool) {
+	date := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	return date, date.Day() == day
+}
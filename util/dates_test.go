@@ -0,0 +1,137 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseDate(t *testing.T) {
+	friday := time.Date(2025, time.January, 31, 15, 4, 0, 0, time.UTC) // Last day of month
+	leapYear := time.Date(2024, time.January, 10, 8, 0, 0, 0, time.UTC)
+	leapDay := time.Date(2024, time.February, 29, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		text string
+		now  time.Time
+		want time.Time
+	}{
+		{"", friday, time.Time{}},
+		{"none", friday, time.Time{}},
+		{"today", friday, day(2025, time.January, 31)},
+		{"Tomorrow", friday, day(2025, time.February, 1)},
+
+		// Weekdays
+		{"fri", friday, day(2025, time.February, 7)},
+		{"friday", friday, day(2025, time.February, 7)},
+		{"sat", friday, day(2025, time.February, 1)},
+		{"monday", friday, day(2025, time.February, 3)},
+		{"thurs", friday, day(2025, time.February, 6)},
+		{"next mon", friday, day(2025, time.February, 3)},
+		{"next fri", friday, day(2025, time.February, 7)},
+		{"next  sunday", friday, day(2025, time.February, 9)},
+		{"eow", friday, day(2025, time.February, 2)},
+
+		// Durations
+		{"in 2 weeks", friday, day(2025, time.February, 14)},
+		{"2w", friday, day(2025, time.February, 14)},
+		{"+3d", friday, day(2025, time.February, 3)},
+		{"in 10 days", friday, day(2025, time.February, 10)},
+
+		// Months at month end
+		{"next month", friday, day(2025, time.February, 1)},
+		{"eom", friday, day(2025, time.January, 31)},
+		{"+1mo", friday, day(2025, time.February, 28)},
+		{"in 2 months", friday, day(2025, time.March, 31)},
+		{"1mo", leapYear, day(2024, time.February, 10)},
+
+		// Feb 29
+		{"feb 29", leapYear, day(2024, time.February, 29)},
+		{"29th february", friday, day(2028, time.February, 29)},
+		{"feb 29 2024", friday, day(2024, time.February, 29)},
+		{"1y", leapDay, day(2025, time.February, 28)},
+		{"+1mo", leapDay, day(2024, time.March, 29)},
+		{"eom", leapDay, day(2024, time.February, 29)},
+
+		// Month and day
+		{"dec 3", friday, day(2025, time.December, 3)},
+		{"jan 15", friday, day(2026, time.January, 15)},
+		{"jan 31", friday, day(2025, time.January, 31)},
+		{"3rd december", friday, day(2025, time.December, 3)},
+		{"dec 3, 2027", friday, day(2027, time.December, 3)},
+		{"2025-06-01", friday, day(2025, time.June, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseDate(tt.text, tt.now)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) failed: %v", tt.text, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %s, want %s", tt.text, got.Format("Mon 2006-01-02"), tt.want.Format("Mon 2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestParseDateInvalid(t *testing.T) {
+	now := time.Date(2025, time.January, 31, 15, 4, 0, 0, time.UTC)
+
+	for _, text := range []string{
+		"someday",
+		"2m", // Minutes, months are mo
+		"in 3 m",
+		"feb 30",
+		"feb 29 2025",
+		"32 jan",
+		"2025-13-01",
+		"next",
+		"next blursday",
+		"3 days ago",
+	} {
+		t.Run(text, func(t *testing.T) {
+			if got, err := ParseDate(text, now); err == nil {
+				t.Errorf("ParseDate(%q) = %s, want error", text, got.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+	now := time.Date(2025, time.January, 31, 15, 4, 0, 0, time.UTC)
+
+	tests := []struct {
+		text string
+		want DateTime
+	}{
+		{"fri 3pm", DateTime{Date: day(2025, time.February, 7), Clock: "15:00"}},
+		{"tomorrow at 9:30", DateTime{Date: day(2025, time.February, 1), Clock: "09:30"}},
+		{"dec 3 5 pm", DateTime{Date: day(2025, time.December, 3), Clock: "17:00"}},
+		{"dec 3 17:00 Europe/Berlin", DateTime{Date: day(2025, time.December, 3), Clock: "17:00", TimeZone: "Europe/Berlin"}},
+		{"noon", DateTime{Date: day(2025, time.January, 31), Clock: "12:00"}},
+		{"dec 3", DateTime{Date: day(2025, time.December, 3)}},
+		{"none", DateTime{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseDateTime(tt.text, now)
+			if err != nil {
+				t.Fatalf("ParseDateTime(%q) failed: %v", tt.text, err)
+			}
+			if !got.Date.Equal(tt.want.Date) || got.Clock != tt.want.Clock || got.TimeZone != tt.want.TimeZone {
+				t.Errorf("ParseDateTime(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+
+	for _, text := range []string{"fri 25:00", "13pm", "fri 3pm Mars/Olympus"} {
+		if got, err := ParseDateTime(text, now); err == nil {
+			t.Errorf("ParseDateTime(%q) = %+v, want error", text, got)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DeepLinkScheme is the URL scheme of links to projects and tasks, e.g. geek-life://task/<UUID>
+const DeepLinkScheme = "geek-life"
+
+// Kinds of records a deep link points to
+const (
+	LinkProject = "project"
+	LinkTask    = "task"
+)
+
+// TaskLink is the deep link of a task
+func TaskLink(UUID string) string {
+	return DeepLinkScheme + "://" + LinkTask + "/" + UUID
+}
+
+// ProjectLink is the deep link of a project
+func ProjectLink(UUID string) string {
+	return DeepLinkScheme + "://" + LinkProject + "/" + UUID
+}
+
+// ParseDeepLink finds kind (LinkProject or LinkTask) and UUID of a deep link
+func ParseDeepLink(link string) (kind, UUID string, err error) {
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Scheme != DeepLinkScheme {
+		return "", "", fmt.Errorf("%q is not a %s:// link", link, DeepLinkScheme)
+	}
+
+	kind, UUID = parsed.Host, strings.Trim(parsed.Path, "/")
+	if (kind != LinkProject && kind != LinkTask) || !IsUUID(UUID) {
+		return "", "", fmt.Errorf("%q is not a link to a project or task", link)
+	}
+
+	return kind, UUID, nil
+}
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FuzzyScore checks if all characters of pattern appear in text in the same order (case-insensitive),
+// and scores the match: higher for consecutive characters and matches at word starts.
+// Empty pattern matches everything with score 0.
+func FuzzyScore(pattern, text string) (int, bool) {
+	patternRunes := []rune(strings.ToLower(pattern))
+	textRunes := []rune(strings.ToLower(text))
+
+	score, next, previous := 0, 0, -2
+	for i, r := range textRunes {
+		if next == len(patternRunes) {
+			break
+		}
+		if r != patternRunes[next] {
+			continue
+		}
+
+		score++
+		if i == previous+1 {
+			score += 2
+		}
+		if i == 0 || !unicode.IsLetter(textRunes[i-1]) && !unicode.IsDigit(textRunes[i-1]) {
+			score += 3
+		}
+		previous = i
+		next++
+	}
+
+	return score, next == len(patternRunes)
+}
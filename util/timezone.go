@@ -0,0 +1,55 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	localZoneOnce sync.Once
+	localZone     string
+)
+
+// SetTimeZone makes the zone of TIME_ZONE setting (e.g. Asia/Dhaka), if set, the local one to show dates and times in.
+// An unknown zone is an error, leaving the system zone in use.
+func SetTimeZone() error {
+	name := os.Getenv("TIME_ZONE")
+	if name == "" {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid TIME_ZONE %q, using the system time zone: %w", name, err)
+	}
+	time.Local = loc
+	return nil
+}
+
+// LocalZoneName is the IANA name of the local time zone (e.g. Asia/Dhaka), to keep with dates set here.
+// It's the zone of TIME_ZONE or TZ setting, or else of the system. Empty if unknown.
+func LocalZoneName() string {
+	localZoneOnce.Do(func() {
+		for _, key := range []string{"TIME_ZONE", "TZ"} {
+			if name := strings.TrimPrefix(os.Getenv(key), ":"); name != "" {
+				if _, err := time.LoadLocation(name); err == nil {
+					localZone = name
+					return
+				}
+			}
+		}
+
+		// e.g. /etc/localtime -> /usr/share/zoneinfo/Asia/Dhaka
+		if target, err := filepath.EvalSymlinks("/etc/localtime"); err == nil {
+			if i := strings.Index(target, "zoneinfo/"); i >= 0 {
+				localZone = target[i+len("zoneinfo/"):]
+			}
+		}
+	})
+
+	return localZone
+}
@@ -14,10 +14,19 @@ import (
 
 	"github.com/asdine/storm/v3"
 	"github.com/mitchellh/go-homedir"
+	bolt "go.etcd.io/bbolt"
 )
 
 // ConnectStorm Create database connection
 func ConnectStorm(dbFilePath string) *storm.DB {
+	db, openErr := OpenStorm(DBPath(dbFilePath), 0)
+	FatalIfError(openErr, "Could not connect Embedded Database File")
+
+	return db
+}
+
+// DBPath finds the database file: dbFilePath if given, or else DB_FILE or ~/.geek-life/default.db
+func DBPath(dbFilePath string) string {
 	var dbPath string
 
 	if dbFilePath != "" {
@@ -46,12 +55,15 @@ func ConnectStorm(dbFilePath string) *storm.DB {
 		}
 	}
 
-	CreateDirIfNotExist(path.Dir(dbPath))
+	return dbPath
+}
 
-	db, openErr := storm.Open(dbPath)
-	FatalIfError(openErr, "Could not connect Embedded Database File")
+// OpenStorm opens the database file. The database is locked while open, so it waits for other processes
+// to close it, up to timeout (0 waits as long as it takes). Returns bolt.ErrTimeout if still locked.
+func OpenStorm(dbPath string, timeout time.Duration) (*storm.DB, error) {
+	CreateDirIfNotExist(path.Dir(dbPath))
 
-	return db
+	return storm.Open(dbPath, storm.BoltOptions(0600, &bolt.Options{Timeout: timeout}))
 }
 
 // CreateDirIfNotExist creates a directory if not found
@@ -64,6 +76,21 @@ func CreateDirIfNotExist(dir string) {
 	}
 }
 
+// AppDir finds the directory for files of geek-life (~/.geek-life), other than the database
+func AppDir() string {
+	dir, err := homedir.Expand("~/.geek-life")
+	if err != nil {
+		return path.Join(os.TempDir(), "geek-life")
+	}
+
+	return dir
+}
+
+// TemplateDir is the directory of user templates. TEMPLATE_DIR overrides default ~/.geek-life/templates
+func TemplateDir() string {
+	return GetEnvStr("TEMPLATE_DIR", path.Join(AppDir(), "templates"))
+}
+
 // UnixToTime create time.Time from string timestamp
 func UnixToTime(timestamp string) time.Time {
 	parts := strings.Split(timestamp, ".")
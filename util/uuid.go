@@ -0,0 +1,40 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"fmt"
+	"regexp"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// NewUUID generates a random (version 4) UUID
+func NewUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return formatUUID(b[:])
+}
+
+// NameUUID generates a name based (version 5 layout) UUID, always the same for the same name
+func NameUUID(name string) string {
+	hash := sha1.Sum([]byte(name))
+	hash[6] = (hash[6] & 0x0f) | 0x50 // Version 5
+	hash[8] = (hash[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return formatUUID(hash[:16])
+}
+
+// IsUUID checks if s is formatted as a UUID
+func IsUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
+
+func formatUUID(b []byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}